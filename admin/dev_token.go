@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"net/http"
+)
+
+type devTokenCmd struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+type devToken struct {
+	Token string `json:"token"`
+}
+
+// DevTokenRoute issues tokens for any email, it must be mounted only in development mode.
+func DevTokenRoute(provider *lib.DevAuthProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := json.NewDecoder(r.Body)
+		payload := devTokenCmd{}
+		if err := d.Decode(&payload); err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		if payload.Email == "" {
+			_ = render.Render(w, r, lib.HttpBadRequest(fmt.Errorf("email is required")))
+			return
+		}
+		token, err := provider.IssueToken(payload.Email, payload.Name)
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(&devToken{Token: token}, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package dice
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// NewServerSeed returns a random seed, kept secret by the server until the end of its session.
+func NewServerSeed() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashSeed is the commitment published for a server seed, the hexadecimal SHA-256 of the seed.
+func HashSeed(seed string) string {
+	h := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(h[:])
+}
+
+// fairSource draws the dice from HMAC-SHA256(server seed, `client seed:nonce:round`),
+// anyone knowing the seeds and the nonce can roll the same dice again.
+type fairSource struct {
+	serverSeed string
+	clientSeed string
+	nonce      int
+	round      int
+	buffer     []byte
+}
+
+// NewFairSource returns the deterministic source of a roll of a provably fair session.
+func NewFairSource(serverSeed string, clientSeed string, nonce int) Source {
+	return &fairSource{serverSeed: serverSeed, clientSeed: clientSeed, nonce: nonce}
+}
+
+func (s *fairSource) next() uint32 {
+	if len(s.buffer) < 4 {
+		mac := hmac.New(sha256.New, []byte(s.serverSeed))
+		_, _ = fmt.Fprintf(mac, "%s:%d:%d", s.clientSeed, s.nonce, s.round)
+		s.buffer = mac.Sum(nil)
+		s.round++
+	}
+	v := binary.BigEndian.Uint32(s.buffer)
+	s.buffer = s.buffer[4:]
+	return v
+}
+
+// Intn rejects the values above the largest multiple of n to stay uniform.
+func (s *fairSource) Intn(n int) int {
+	limit := (1 << 32) / uint64(n) * uint64(n)
+	for {
+		if v := uint64(s.next()); v < limit {
+			return int(v % uint64(n))
+		}
+	}
+}
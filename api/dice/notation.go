@@ -0,0 +1,318 @@
+package dice
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxExpressionLength = 256
+	maxExpressionDepth  = 16
+	maxCount            = 100
+	maxSides            = 1000
+	maxNumber           = 1000000
+)
+
+// Expression is a parsed dice expression, like `4d6kh3 + 2`.
+//
+// Groups of dice are written `NdM`, `dF` for Fate dice and `d%` for percentile dice, followed by modifiers:
+//
+//	!  or !>N   explode on the maximum, or on the compare point
+//	r  or r<N   reroll the ones until they do not match, ro rerolls once
+//	khN, klN    keep the N highest or lowest dice, kN is khN
+//	dhN, dlN    drop the N highest or lowest dice
+//	>=N         count the successes instead of summing, fN counts the failures to subtract
+//
+// Groups and numbers are combined with + - * / and parentheses.
+type Expression struct {
+	source string
+	root   node
+}
+
+func (e *Expression) String() string { return e.source }
+
+// Parse checks the syntax of an expression and the limits of its groups.
+func Parse(source string) (*Expression, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+	if len(source) > maxExpressionLength {
+		return nil, fmt.Errorf("expression longer than %d characters", maxExpressionLength)
+	}
+	p := &parser{runes: []rune(strings.ToLower(source))}
+	root, err := p.parseSum(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at %d", p.peek(), p.pos)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// Parser, by precedence: + - then * / then unary minus.
+
+type parser struct {
+	runes []rune
+	pos   int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.runes) }
+
+func (p *parser) peek() rune {
+	if p.done() {
+		return 0
+	}
+	return p.runes[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// accept consumes the prefix if present.
+func (p *parser) accept(prefix string) bool {
+	if strings.HasPrefix(string(p.runes[p.pos:]), prefix) {
+		p.pos += len([]rune(prefix))
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseSum(depth int) (node, error) {
+	if depth > maxExpressionDepth {
+		return nil, fmt.Errorf("expression nested deeper than %d", maxExpressionDepth)
+	}
+	left, err := p.parseProduct(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseProduct(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseProduct(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	p.skipSpaces()
+	if p.accept("-") {
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{operand: operand}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	p.skipSpaces()
+	start := p.pos
+	switch r := p.peek(); {
+	case r == '(':
+		p.pos++
+		n, err := p.parseSum(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.accept(")") {
+			return nil, fmt.Errorf("expected ) at %d", p.pos)
+		}
+		return n, nil
+	case unicode.IsDigit(r):
+		value, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != 'd' {
+			return numberNode(value), nil
+		}
+		return p.parseGroup(start, value)
+	case r == 'd':
+		return p.parseGroup(start, 1)
+	case r == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", r, p.pos)
+	}
+}
+
+func (p *parser) parseNumber() (int, error) {
+	start := p.pos
+	value := 0
+	for !p.done() && unicode.IsDigit(p.peek()) {
+		value = value*10 + int(p.peek()-'0')
+		if value > maxNumber {
+			return 0, fmt.Errorf("number at %d greater than %d", start, maxNumber)
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, fmt.Errorf("expected a number at %d", p.pos)
+	}
+	return value, nil
+}
+
+// parseGroup reads the dice after their count, the modifiers included.
+func (p *parser) parseGroup(start int, count int) (node, error) {
+	p.pos++
+	g := &groupNode{count: count}
+	switch {
+	case p.accept("%"):
+		g.sides = 100
+	case p.accept("f"):
+		g.fate = true
+		g.sides = 3
+	default:
+		sides, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		g.sides = sides
+	}
+	if g.count < 1 || g.count > maxCount {
+		return nil, fmt.Errorf("number of dice at %d must be between 1 and %d", start, maxCount)
+	}
+	if g.sides < 1 || g.sides > maxSides {
+		return nil, fmt.Errorf("number of sides at %d must be between 1 and %d", start, maxSides)
+	}
+
+	for {
+		at := p.pos
+		var err error
+		switch {
+		case p.accept("!"):
+			if g.explode != nil {
+				return nil, fmt.Errorf("dice explode twice at %d", at)
+			}
+			g.explode, err = p.parseCompare(compare{op: "=", value: g.max()}, false)
+			if err == nil && g.matchesAll(g.explode) {
+				err = fmt.Errorf("dice at %d would explode forever", at)
+			}
+		case p.accept("ro"), p.accept("r"):
+			if g.reroll != nil {
+				return nil, fmt.Errorf("dice rerolled twice at %d", at)
+			}
+			g.rerollOnce = strings.HasPrefix(string(p.runes[at:]), "ro")
+			g.reroll, err = p.parseCompare(compare{op: "=", value: g.min()}, true)
+			if err == nil && g.matchesAll(g.reroll) {
+				err = fmt.Errorf("dice at %d would be rerolled forever", at)
+			}
+		case p.accept("kh"), p.accept("kl"), p.accept("k"), p.accept("dh"), p.accept("dl"):
+			if g.selection != "" {
+				return nil, fmt.Errorf("dice kept or dropped twice at %d", at)
+			}
+			g.selection = string(p.runes[at:p.pos])
+			if g.selection == "k" {
+				g.selection = "kh"
+			}
+			g.selected, err = p.parseNumber()
+			if err == nil && g.selected < 1 {
+				err = fmt.Errorf("number of dice kept or dropped at %d must be positive", at)
+			}
+		case p.peek() == '>' || p.peek() == '<' || p.peek() == '=':
+			if g.success != nil {
+				return nil, fmt.Errorf("successes counted twice at %d", at)
+			}
+			g.success, err = p.parseCompare(compare{}, false)
+		case p.accept("f"):
+			if g.success == nil || g.failure != nil {
+				return nil, fmt.Errorf("failures must follow the successes at %d", at)
+			}
+			g.failure, err = p.parseCompare(compare{}, true)
+		default:
+			g.notation = string(p.runes[start:p.pos])
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseCompare reads a compare point like `>=5`, the default is used when there is none.
+// A bare number means equality when allowed.
+func (p *parser) parseCompare(def compare, bare bool) (*compare, error) {
+	at := p.pos
+	c := compare{}
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if p.accept(op) {
+			c.op = op
+			break
+		}
+	}
+	if c.op == "" {
+		if bare && unicode.IsDigit(p.peek()) {
+			c.op = "="
+		} else if def.op != "" {
+			return &def, nil
+		} else {
+			return nil, fmt.Errorf("expected a compare point at %d", at)
+		}
+	}
+	negative := p.accept("-")
+	value, err := p.parseNumber()
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		value = -value
+	}
+	c.value = value
+	return &c, nil
+}
+
+type compare struct {
+	op    string
+	value int
+}
+
+func (c *compare) matches(v int) bool {
+	switch c.op {
+	case ">=":
+		return v >= c.value
+	case "<=":
+		return v <= c.value
+	case ">":
+		return v > c.value
+	case "<":
+		return v < c.value
+	default:
+		return v == c.value
+	}
+}
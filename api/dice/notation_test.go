@@ -0,0 +1,144 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseValid(t *testing.T) {
+	tests := []string{
+		"1d20",
+		"d20",
+		"1D20",
+		" 2d6 + 3 ",
+		"d%",
+		"4dF",
+		"4d6kh3",
+		"4d6k3",
+		"4d6kl1",
+		"4d6dh1",
+		"4d6dl1",
+		"3d6!",
+		"3d6!>5",
+		"3d6!>=5",
+		"2d10r",
+		"2d10r<3",
+		"2d10r2",
+		"2d10ro",
+		"2d10ro1",
+		"5d10>=8",
+		"5d10>=8f1",
+		"5d10=10f<=2",
+		"4dF>=1f<0",
+		"10d6!>=5r1kh3",
+		"(1d6 + 2) * 3",
+		"-1d4",
+		"--2",
+		"2d6 - 1d4 / 2",
+		"100d6",
+		"1d1000",
+		"1000000",
+	}
+	for _, source := range tests {
+		t.Run(source, func(t *testing.T) {
+			e, err := Parse(source)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if e.String() != strings.TrimSpace(source) {
+				t.Errorf("expected %q, got %q", strings.TrimSpace(source), e.String())
+			}
+		})
+	}
+}
+
+func TestParseRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"empty", ""},
+		{"blank", "   "},
+		{"too long", strings.Repeat("1+", 128) + "1"},
+		{"too deep", strings.Repeat("(", 17) + "1" + strings.Repeat(")", 17)},
+		{"missing sides", "1d"},
+		{"missing dice", "d"},
+		{"no dice", "0d6"},
+		{"too many dice", "101d6"},
+		{"no sides", "1d0"},
+		{"too many sides", "1d1001"},
+		{"number too large", "1000001"},
+		{"explode twice", "1d6!!"},
+		{"explode forever", "1d1!"},
+		{"explode forever on compare point", "1d6!>=1"},
+		{"reroll twice", "1d6rr"},
+		{"reroll forever", "1d6r<=6"},
+		{"reroll fate forever", "1dFr>-2"},
+		{"keep twice", "4d6kh1kl1"},
+		{"keep none", "4d6kh0"},
+		{"keep without number", "4d6kh"},
+		{"successes twice", "1d6>3>4"},
+		{"failures without successes", "1d6f1"},
+		{"failures twice", "1d6>3f1f2"},
+		{"compare point without number", "1d6>"},
+		{"trailing operator", "1d6+"},
+		{"unclosed parenthesis", "(1d6"},
+		{"unopened parenthesis", "1d6)"},
+		{"unknown operator", "2x3"},
+		{"unknown modifier", "1d6z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(test.source); err == nil {
+				t.Errorf("%q must be rejected", test.source)
+			}
+		})
+	}
+}
+
+func TestParseKeepAlias(t *testing.T) {
+	e, err := Parse("4d6k3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := e.root.(*groupNode); g.selection != "kh" || g.selected != 3 {
+		t.Errorf("k3 must keep the 3 highest dice, got %s%d", g.selection, g.selected)
+	}
+}
+
+func TestParseDefaultCompares(t *testing.T) {
+	tests := []struct {
+		source  string
+		explode *compare
+		reroll  *compare
+	}{
+		{"1d6!", &compare{op: "=", value: 6}, nil},
+		{"1d6r", nil, &compare{op: "=", value: 1}},
+		{"1d6r3", nil, &compare{op: "=", value: 3}},
+		{"1dF!", &compare{op: "=", value: 1}, nil},
+		{"1dFr", nil, &compare{op: "=", value: -1}},
+		{"1d%!", &compare{op: "=", value: 100}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			e, err := Parse(test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := e.root.(*groupNode)
+			if !sameCompare(g.explode, test.explode) {
+				t.Errorf("expected explode %v, got %v", test.explode, g.explode)
+			}
+			if !sameCompare(g.reroll, test.reroll) {
+				t.Errorf("expected reroll %v, got %v", test.reroll, g.reroll)
+			}
+		})
+	}
+}
+
+func sameCompare(a, b *compare) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
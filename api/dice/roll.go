@@ -0,0 +1,264 @@
+package dice
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+const (
+	// Dice rolled by an expression, the rerolls and the explosions included.
+	maxRolledDice = 500
+	maxTotal      = 1 << 40
+)
+
+// Source draws the dice, Intn returns a number in [0, n).
+type Source interface {
+	Intn(n int) int
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Intn(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(fmt.Errorf("cannot read random numbers: %w", err))
+	}
+	return int(v.Int64())
+}
+
+// CryptoSource draws the dice from the random generator of the system.
+var CryptoSource Source = cryptoSource{}
+
+// Die is one die rolled.
+type Die struct {
+	Value int `json:"value" bson:"value"`
+	// Dropped dice do not count in the total, they were not kept or were rerolled.
+	Dropped  bool `json:"dropped" bson:"dropped"`
+	Rerolled bool `json:"rerolled" bson:"rerolled"`
+	// Exploded dice triggered the roll of the next die.
+	Exploded bool `json:"exploded" bson:"exploded"`
+	Success  bool `json:"success" bson:"success"`
+	Failure  bool `json:"failure" bson:"failure"`
+}
+
+// Group is the roll of the dice of a group like `4d6kh3`.
+type Group struct {
+	Notation string `json:"notation" bson:"notation"`
+	// Sides of the dice, 3 for the Fate dice which roll -1, 0 or 1.
+	Sides int   `json:"sides" bson:"sides"`
+	Fate  bool  `json:"fate" bson:"fate"`
+	Dice  []Die `json:"dice" bson:"dice"`
+	// Sum of the counted dice, or the successes minus the failures.
+	Total int `json:"total" bson:"total"`
+}
+
+// Result is the roll of an expression, with every die.
+type Result struct {
+	Expression string  `json:"expression" bson:"expression"`
+	Groups     []Group `json:"groups" bson:"groups"`
+	Total      int     `json:"total" bson:"total"`
+}
+
+// Roll parses and rolls an expression.
+func Roll(source string, src Source) (*Result, error) {
+	e, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return e.Roll(src)
+}
+
+// Roll draws the dice of the expression from the source.
+func (e *Expression) Roll(src Source) (*Result, error) {
+	r := &roller{src: src, result: &Result{Expression: e.source, Groups: []Group{}}}
+	total, err := e.root.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	r.result.Total = total
+	return r.result, nil
+}
+
+type roller struct {
+	src    Source
+	rolled int
+	result *Result
+}
+
+func (r *roller) roll(g *groupNode) (int, error) {
+	r.rolled++
+	if r.rolled > maxRolledDice {
+		return 0, fmt.Errorf("more than %d dice rolled", maxRolledDice)
+	}
+	return r.src.Intn(g.sides) + g.min(), nil
+}
+
+type node interface {
+	eval(r *roller) (int, error)
+	distribution(b *budget) (*distribution, error)
+}
+
+type numberNode int
+
+func (n numberNode) eval(*roller) (int, error) { return int(n), nil }
+
+type negateNode struct {
+	operand node
+}
+
+func (n *negateNode) eval(r *roller) (int, error) {
+	v, err := n.operand.eval(r)
+	return -v, err
+}
+
+type binaryNode struct {
+	op          rune
+	left, right node
+}
+
+func (n *binaryNode) eval(r *roller) (int, error) {
+	l, err := n.left.eval(r)
+	if err != nil {
+		return 0, err
+	}
+	rv, err := n.right.eval(r)
+	if err != nil {
+		return 0, err
+	}
+	var v int
+	switch n.op {
+	case '+':
+		v = l + rv
+	case '-':
+		v = l - rv
+	case '*':
+		v = l * rv
+	default:
+		if rv == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		v = l / rv
+	}
+	if v > maxTotal || v < -maxTotal {
+		return 0, fmt.Errorf("total out of range")
+	}
+	return v, nil
+}
+
+type groupNode struct {
+	notation   string
+	count      int
+	sides      int
+	fate       bool
+	explode    *compare
+	reroll     *compare
+	rerollOnce bool
+	selection  string // kh, kl, dh or dl
+	selected   int
+	success    *compare
+	failure    *compare
+}
+
+func (g *groupNode) min() int {
+	if g.fate {
+		return -1
+	}
+	return 1
+}
+
+func (g *groupNode) max() int { return g.min() + g.sides - 1 }
+
+// matchesAll tells whether every face matches the compare point.
+func (g *groupNode) matchesAll(c *compare) bool {
+	for v := g.min(); v <= g.max(); v++ {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *groupNode) eval(r *roller) (int, error) {
+	dice := make([]Die, 0, g.count)
+	for i := 0; i < g.count; i++ {
+		value, err := r.roll(g)
+		if err != nil {
+			return 0, err
+		}
+		for g.reroll != nil && g.reroll.matches(value) {
+			dice = append(dice, Die{Value: value, Dropped: true, Rerolled: true})
+			if value, err = r.roll(g); err != nil {
+				return 0, err
+			}
+			if g.rerollOnce {
+				break
+			}
+		}
+		die := Die{Value: value}
+		for g.explode != nil && g.explode.matches(die.Value) {
+			die.Exploded = true
+			dice = append(dice, die)
+			if value, err = r.roll(g); err != nil {
+				return 0, err
+			}
+			die = Die{Value: value}
+		}
+		dice = append(dice, die)
+	}
+	g.selectDice(dice)
+
+	total := 0
+	for i := range dice {
+		d := &dice[i]
+		if d.Dropped {
+			continue
+		}
+		switch {
+		case g.success == nil:
+			total += d.Value
+		case g.success.matches(d.Value):
+			d.Success = true
+			total++
+		case g.failure != nil && g.failure.matches(d.Value):
+			d.Failure = true
+			total--
+		}
+	}
+	r.result.Groups = append(r.result.Groups, Group{Notation: g.notation, Sides: g.sides, Fate: g.fate, Dice: dice, Total: total})
+	return total, nil
+}
+
+// selectDice drops the dice not kept, among the dice not rerolled.
+func (g *groupNode) selectDice(dice []Die) {
+	if g.selection == "" {
+		return
+	}
+	candidates := make([]int, 0, len(dice))
+	for i, d := range dice {
+		if !d.Rerolled {
+			candidates = append(candidates, i)
+		}
+	}
+	// Lowest first.
+	sort.SliceStable(candidates, func(i, j int) bool { return dice[candidates[i]].Value < dice[candidates[j]].Value })
+	n := g.selected
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	var dropped []int
+	switch g.selection {
+	case "kh":
+		dropped = candidates[:len(candidates)-n]
+	case "kl":
+		dropped = candidates[n:]
+	case "dh":
+		dropped = candidates[len(candidates)-n:]
+	default:
+		dropped = candidates[:n]
+	}
+	for _, i := range dropped {
+		dice[i].Dropped = true
+	}
+}
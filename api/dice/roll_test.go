@@ -0,0 +1,182 @@
+package dice
+
+import (
+	"testing"
+)
+
+// fixedSource draws the given faces in order, for dice whose lowest face is 1.
+type fixedSource struct {
+	t     *testing.T
+	faces []int
+}
+
+func (s *fixedSource) Intn(n int) int {
+	if len(s.faces) == 0 {
+		s.t.Fatal("no more dice to draw")
+	}
+	face := s.faces[0]
+	s.faces = s.faces[1:]
+	if face < 1 || face > n {
+		s.t.Fatalf("face %d out of a die of %d sides", face, n)
+	}
+	return face - 1
+}
+
+// constSource always draws the same face, for dice whose lowest face is 1.
+type constSource int
+
+func (s constSource) Intn(int) int { return int(s) - 1 }
+
+func TestRoll(t *testing.T) {
+	tests := []struct {
+		source string
+		faces  []int
+		total  int
+		// Value of each die, with the flags which are set, in order.
+		dice []Die
+	}{
+		{"2d6+3", []int{2, 5}, 10, []Die{{Value: 2}, {Value: 5}}},
+		{"d%", []int{42}, 42, []Die{{Value: 42}}},
+		// Fate dice draw -1, 0 or 1.
+		{"4dF", []int{1, 2, 3, 3}, 1, []Die{{Value: -1}, {Value: 0}, {Value: 1}, {Value: 1}}},
+		{"4d6kh3", []int{1, 4, 6, 3}, 13, []Die{{Value: 1, Dropped: true}, {Value: 4}, {Value: 6}, {Value: 3}}},
+		{"4d6dl1", []int{1, 4, 6, 3}, 13, []Die{{Value: 1, Dropped: true}, {Value: 4}, {Value: 6}, {Value: 3}}},
+		{"4d6kl1", []int{2, 4, 6, 3}, 2, []Die{{Value: 2}, {Value: 4, Dropped: true}, {Value: 6, Dropped: true}, {Value: 3, Dropped: true}}},
+		{"4d6dh1", []int{2, 4, 6, 3}, 9, []Die{{Value: 2}, {Value: 4}, {Value: 6, Dropped: true}, {Value: 3}}},
+		// Keeping more dice than rolled keeps them all.
+		{"2d6kh3", []int{2, 4}, 6, []Die{{Value: 2}, {Value: 4}}},
+		// Ties are broken by order, the first lowest die is dropped.
+		{"3d6dl1", []int{3, 3, 5}, 8, []Die{{Value: 3, Dropped: true}, {Value: 3}, {Value: 5}}},
+		{"3d6!", []int{6, 2, 3, 4}, 15, []Die{{Value: 6, Exploded: true}, {Value: 2}, {Value: 3}, {Value: 4}}},
+		{"1d6!", []int{6, 6, 1}, 13, []Die{{Value: 6, Exploded: true}, {Value: 6, Exploded: true}, {Value: 1}}},
+		{"2d6!>=5", []int{5, 6, 1, 2}, 14, []Die{{Value: 5, Exploded: true}, {Value: 6, Exploded: true}, {Value: 1}, {Value: 2}}},
+		{"2d6r", []int{1, 1, 3, 5}, 8, []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 1, Dropped: true, Rerolled: true}, {Value: 3}, {Value: 5}}},
+		{"2d6r<3", []int{2, 1, 3, 4}, 7, []Die{{Value: 2, Dropped: true, Rerolled: true}, {Value: 1, Dropped: true, Rerolled: true}, {Value: 3}, {Value: 4}}},
+		// Rerolled once, even when the reroll matches again.
+		{"2d6ro", []int{1, 1, 5}, 6, []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 1}, {Value: 5}}},
+		// The rerolled die explodes, the dice of the explosion are not rerolled.
+		{"1d6r1!", []int{1, 6, 1}, 7, []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 6, Exploded: true}, {Value: 1}}},
+		// The dice of an explosion are kept or dropped one by one.
+		{"2d6!kh1", []int{6, 2, 5}, 6, []Die{{Value: 6, Exploded: true}, {Value: 2, Dropped: true}, {Value: 5, Dropped: true}}},
+		// The rerolled dice are not among the kept ones.
+		{"3d6rkh2", []int{1, 4, 2, 6}, 10, []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 4}, {Value: 2, Dropped: true}, {Value: 6}}},
+		{"5d10>=8", []int{8, 10, 3, 7, 1}, 2, []Die{{Value: 8, Success: true}, {Value: 10, Success: true}, {Value: 3}, {Value: 7}, {Value: 1}}},
+		{"5d10>=8f1", []int{8, 10, 3, 7, 1}, 1, []Die{{Value: 8, Success: true}, {Value: 10, Success: true}, {Value: 3}, {Value: 7}, {Value: 1, Failure: true}}},
+		// The compare point after ! is the one of the explosion, the successes are counted before.
+		{"3d10!>=8", []int{10, 8, 2, 3, 4}, 27, []Die{{Value: 10, Exploded: true}, {Value: 8, Exploded: true}, {Value: 2}, {Value: 3}, {Value: 4}}},
+		// The exploded dice count as successes too.
+		{"3d10>=8!", []int{10, 9, 2, 8}, 3, []Die{{Value: 10, Exploded: true, Success: true}, {Value: 9, Success: true}, {Value: 2}, {Value: 8, Success: true}}},
+		// The dropped dice are neither successes nor failures.
+		{"4d10kh2>=8f1", []int{9, 1, 8, 1}, 2, []Die{{Value: 9, Success: true}, {Value: 1, Dropped: true}, {Value: 8, Success: true}, {Value: 1, Dropped: true}}},
+		{"2d10>=8ro", []int{1, 8, 4}, 1, []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 8, Success: true}, {Value: 4}}},
+		{"1d4 - 1d6", []int{3, 5}, -2, []Die{{Value: 3}, {Value: 5}}},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			src := &fixedSource{t: t, faces: test.faces}
+			res, err := Roll(test.source, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(src.faces) > 0 {
+				t.Errorf("%d dice not drawn", len(src.faces))
+			}
+			if res.Total != test.total {
+				t.Errorf("expected total %d, got %d", test.total, res.Total)
+			}
+			dice := make([]Die, 0)
+			for _, g := range res.Groups {
+				dice = append(dice, g.Dice...)
+			}
+			if len(dice) != len(test.dice) {
+				t.Fatalf("expected dice %+v, got %+v", test.dice, dice)
+			}
+			for i := range dice {
+				if dice[i] != test.dice[i] {
+					t.Errorf("expected die %d %+v, got %+v", i, test.dice[i], dice[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRollArithmetic(t *testing.T) {
+	tests := []struct {
+		source string
+		total  int
+	}{
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"7 / 2", 3},
+		{"-7 / 2", -3},
+		{"-2 * 3", -6},
+		{"--2", 2},
+		{"2 * -3", -6},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			res, err := Roll(test.source, &fixedSource{t: t})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Total != test.total {
+				t.Errorf("expected %d, got %d", test.total, res.Total)
+			}
+		})
+	}
+}
+
+func TestRollGroups(t *testing.T) {
+	res, err := Roll(" 4d6kh3 + 1d8 - 2 ", &fixedSource{t: t, faces: []int{1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Expression != "4d6kh3 + 1d8 - 2" {
+		t.Errorf("unexpected expression %q", res.Expression)
+	}
+	if len(res.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(res.Groups))
+	}
+	if g := res.Groups[0]; g.Notation != "4d6kh3" || g.Sides != 6 || g.Total != 9 {
+		t.Errorf("unexpected group %+v", g)
+	}
+	if g := res.Groups[1]; g.Notation != "1d8" || g.Sides != 8 || g.Total != 5 {
+		t.Errorf("unexpected group %+v", g)
+	}
+	if res.Total != 12 {
+		t.Errorf("expected 12, got %d", res.Total)
+	}
+}
+
+func TestRollLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		src    Source
+		valid  bool
+	}{
+		{"most dice", "100d6", constSource(3), true},
+		{"most dice with explosions", "100d6!", constSource(6), false},
+		{"most dice with rerolls", "100d6r", constSource(1), false},
+		// Exploding forever is stopped by the limit of rolled dice.
+		{"endless explosion", "1d6!", constSource(6), false},
+		{"dice across groups", "100d6 + 100d6 + 100d6 + 100d6 + 100d6", constSource(1), true},
+		{"too many dice across groups", "100d6 + 100d6 + 100d6 + 100d6 + 100d6 + 1d6", constSource(1), false},
+		{"division by zero", "1d6 / (1d6 - 1d6)", constSource(4), false},
+		{"total in range", "1000000 * 1000000", constSource(1), true},
+		{"total out of range", "1000000 * 1000000 * 1000000", constSource(1), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Roll(test.source, test.src)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
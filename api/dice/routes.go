@@ -0,0 +1,56 @@
+package dice
+
+import (
+	"fmt"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"net/http"
+	"strconv"
+)
+
+// Odds of reaching a target, like the difficulty of a check.
+type Odds struct {
+	Target  int     `json:"target"`
+	AtLeast float64 `json:"atLeast"`
+	AtMost  float64 `json:"atMost"`
+}
+
+type statsResponse struct {
+	*Stats
+	Odds *Odds `json:"odds,omitempty"`
+}
+
+func statsRoute(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	e, err := Parse(query.Get("expr"))
+	if err != nil {
+		_ = render.Render(w, r, lib.HttpBadRequest(err))
+		return
+	}
+	res := &statsResponse{}
+	if target := query.Get("target"); target != "" {
+		value, err := strconv.Atoi(target)
+		if err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(fmt.Errorf("target must be an integer")))
+			return
+		}
+		res.Odds = &Odds{Target: value}
+	}
+	if res.Stats, err = e.Stats(); err != nil {
+		_ = render.Render(w, r, lib.HttpBadRequest(err))
+		return
+	}
+	if res.Odds != nil {
+		res.Odds.AtLeast = res.Stats.AtLeast(res.Odds.Target)
+		res.Odds.AtMost = res.Stats.AtMost(res.Odds.Target)
+	}
+	if err := render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+		_ = render.Render(w, r, lib.HttpRenderError(err))
+		return
+	}
+}
+
+func Route(router chi.Router) {
+	router.Get("/stats", statsRoute)
+}
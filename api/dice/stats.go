@@ -0,0 +1,480 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	// Budget of the exact computation, in elementary operations, beyond it the stats are simulated.
+	maxExactOperations = 50000000
+	maxExactSupport    = 20000
+	simulatedRolls     = 20000
+	// Explosions are followed until the probability to explode again is negligible.
+	negligible = 1e-12
+)
+
+var errTooComplex = errors.New("too complex to compute exactly")
+
+// Outcome is a total of an expression with its probability.
+type Outcome struct {
+	Total       int     `json:"total"`
+	Probability float64 `json:"probability"`
+	// Probabilities to roll at least and at most the total.
+	AtLeast float64 `json:"atLeast"`
+	AtMost  float64 `json:"atMost"`
+}
+
+// Stats is the probability distribution of the totals of an expression.
+type Stats struct {
+	Expression string `json:"expression"`
+	// Exact stats are computed, the others are estimated from simulated rolls.
+	Exact     bool      `json:"exact"`
+	Samples   int       `json:"samples,omitempty"`
+	Min       int       `json:"min"`
+	Max       int       `json:"max"`
+	Mean      float64   `json:"mean"`
+	StdDev    float64   `json:"stdDev"`
+	Histogram []Outcome `json:"histogram"`
+}
+
+// AtLeast returns the probability to roll at least the total.
+func (s *Stats) AtLeast(total int) float64 {
+	i := sort.Search(len(s.Histogram), func(i int) bool { return s.Histogram[i].Total >= total })
+	if i == len(s.Histogram) {
+		return 0
+	}
+	return s.Histogram[i].AtLeast
+}
+
+// AtMost returns the probability to roll at most the total.
+func (s *Stats) AtMost(total int) float64 {
+	i := sort.Search(len(s.Histogram), func(i int) bool { return s.Histogram[i].Total > total })
+	if i == 0 {
+		return 0
+	}
+	return s.Histogram[i-1].AtMost
+}
+
+// Stats computes the distribution of the totals of the expression by convolution of the distributions of its groups.
+// Expressions too complex for the budget, like the kept or dropped exploding dice, are simulated instead.
+func (e *Expression) Stats() (*Stats, error) {
+	d, err := e.root.distribution(&budget{})
+	if err == errTooComplex {
+		return e.simulate(simulatedRolls)
+	}
+	if err != nil {
+		return nil, err
+	}
+	outcomes := make(map[int]float64, len(d.probs))
+	for i, p := range d.probs {
+		if p > 0 {
+			outcomes[d.min+i] = p
+		}
+	}
+	return newStats(e.source, outcomes, true, 0), nil
+}
+
+func (e *Expression) simulate(rolls int) (*Stats, error) {
+	src := rand.New(rand.NewSource(time.Now().UnixNano()))
+	counts := make(map[int]int)
+	for i := 0; i < rolls; i++ {
+		r, err := e.Roll(src)
+		if err != nil {
+			return nil, err
+		}
+		counts[r.Total]++
+	}
+	outcomes := make(map[int]float64, len(counts))
+	for total, count := range counts {
+		outcomes[total] = float64(count) / float64(rolls)
+	}
+	return newStats(e.source, outcomes, false, rolls), nil
+}
+
+func newStats(expression string, outcomes map[int]float64, exact bool, samples int) *Stats {
+	res := &Stats{Expression: expression, Exact: exact, Samples: samples, Histogram: make([]Outcome, 0, len(outcomes))}
+	totals := make([]int, 0, len(outcomes))
+	for total := range outcomes {
+		totals = append(totals, total)
+	}
+	sort.Ints(totals)
+	cumulated := 0.0
+	for _, total := range totals {
+		p := outcomes[total]
+		res.Mean += float64(total) * p
+		res.Histogram = append(res.Histogram, Outcome{Total: total, Probability: p, AtLeast: math.Max(1-cumulated, 0)})
+		cumulated += p
+		res.Histogram[len(res.Histogram)-1].AtMost = math.Min(cumulated, 1)
+	}
+	variance := 0.0
+	for _, total := range totals {
+		variance += math.Pow(float64(total)-res.Mean, 2) * outcomes[total]
+	}
+	res.StdDev = math.Sqrt(variance)
+	if len(totals) > 0 {
+		res.Min, res.Max = totals[0], totals[len(totals)-1]
+	}
+	return res
+}
+
+// Distributions
+
+type budget struct {
+	operations int
+}
+
+func (b *budget) spend(operations int) error {
+	b.operations += operations
+	if b.operations > maxExactOperations {
+		return errTooComplex
+	}
+	return nil
+}
+
+// distribution is the probability of each total from min.
+type distribution struct {
+	min   int
+	probs []float64
+}
+
+func point(v int) *distribution { return &distribution{min: v, probs: []float64{1}} }
+
+func newDistribution(min int, max int) (*distribution, error) {
+	if max-min+1 > maxExactSupport {
+		return nil, errTooComplex
+	}
+	return &distribution{min: min, probs: make([]float64, max-min+1)}, nil
+}
+
+func (d *distribution) max() int { return d.min + len(d.probs) - 1 }
+
+// addTo adds the distribution, shifted and weighted, to the result.
+func (d *distribution) addTo(res *distribution, shift int, weight float64) {
+	for i, p := range d.probs {
+		res.probs[d.min+shift+i-res.min] += p * weight
+	}
+}
+
+// sum is the distribution of the sum of independent totals.
+func (d *distribution) sum(o *distribution, b *budget) (*distribution, error) {
+	if err := b.spend(len(d.probs) * len(o.probs)); err != nil {
+		return nil, err
+	}
+	res, err := newDistribution(d.min+o.min, d.max()+o.max())
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range d.probs {
+		if p > 0 {
+			o.addTo(res, d.min+i, p)
+		}
+	}
+	return res, nil
+}
+
+func (d *distribution) combine(o *distribution, b *budget, op func(l, r int) (int, error)) (*distribution, error) {
+	if err := b.spend(len(d.probs) * len(o.probs)); err != nil {
+		return nil, err
+	}
+	outcomes := make(map[int]float64)
+	min, max := math.MaxInt64, math.MinInt64
+	for i, p := range d.probs {
+		for j, q := range o.probs {
+			if p == 0 || q == 0 {
+				continue
+			}
+			v, err := op(d.min+i, o.min+j)
+			if err != nil {
+				return nil, err
+			}
+			outcomes[v] += p * q
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+	}
+	res, err := newDistribution(min, max)
+	if err != nil {
+		return nil, err
+	}
+	for v, p := range outcomes {
+		res.probs[v-min] = p
+	}
+	return res, nil
+}
+
+func (n numberNode) distribution(*budget) (*distribution, error) { return point(int(n)), nil }
+
+func (n *negateNode) distribution(b *budget) (*distribution, error) {
+	d, err := n.operand.distribution(b)
+	if err != nil {
+		return nil, err
+	}
+	res := &distribution{min: -d.max(), probs: make([]float64, len(d.probs))}
+	for i, p := range d.probs {
+		res.probs[len(d.probs)-1-i] = p
+	}
+	return res, nil
+}
+
+func (n *binaryNode) distribution(b *budget) (*distribution, error) {
+	l, err := n.left.distribution(b)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.distribution(b)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case '+':
+		return l.sum(r, b)
+	case '-':
+		return l.combine(r, b, func(x, y int) (int, error) { return x - y, nil })
+	case '*':
+		return l.combine(r, b, func(x, y int) (int, error) { return x * y, nil })
+	default:
+		return l.combine(r, b, func(x, y int) (int, error) {
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		})
+	}
+}
+
+// contribution of a die to the total of its group, its value or whether it is a success or a failure.
+func (g *groupNode) contribution(v int) int {
+	switch {
+	case g.success == nil:
+		return v
+	case g.success.matches(v):
+		return 1
+	case g.failure != nil && g.failure.matches(v):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// faces returns the probability of each face of a die after its rerolls, from the minimum.
+func (g *groupNode) faces() []float64 {
+	res := make([]float64, g.sides)
+	n := float64(g.sides)
+	matching := 0
+	for v := g.min(); v <= g.max(); v++ {
+		if g.reroll != nil && g.reroll.matches(v) {
+			matching++
+		}
+	}
+	for i := range res {
+		rerolled := g.reroll != nil && g.reroll.matches(g.min()+i)
+		switch {
+		case g.reroll == nil:
+			res[i] = 1 / n
+		case g.rerollOnce:
+			res[i] = float64(matching) / n / n
+			if !rerolled {
+				res[i] += 1 / n
+			}
+		case !rerolled:
+			res[i] = 1 / (n - float64(matching))
+		}
+	}
+	return res
+}
+
+func (g *groupNode) distribution(b *budget) (*distribution, error) {
+	faces := g.faces()
+	if g.selection != "" {
+		return g.selectedDistribution(faces, b)
+	}
+
+	// Contribution of the dice rolled after an explosion, followed while they could explode again.
+	var exploded *distribution
+	if g.explode != nil {
+		exploding := 0
+		for v := g.min(); v <= g.max(); v++ {
+			if g.explode.matches(v) {
+				exploding++
+			}
+		}
+		depth := int(math.Ceil(math.Log(negligible)/math.Log(float64(exploding)/float64(g.sides)))) + 1
+		if depth > maxRolledDice {
+			depth = maxRolledDice
+		}
+		uniform := make([]float64, g.sides)
+		for i := range uniform {
+			uniform[i] = 1 / float64(g.sides)
+		}
+		for i := 0; i < depth; i++ {
+			next, err := g.chain(uniform, exploded, b)
+			if err != nil {
+				return nil, err
+			}
+			exploded = next
+		}
+	}
+	die, err := g.chain(faces, exploded, b)
+	if err != nil {
+		return nil, err
+	}
+	res := point(0)
+	for i := 0; i < g.count; i++ {
+		if res, err = res.sum(die, b); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// chain is the distribution of the contribution of a die, with the dice rolled when it explodes.
+func (g *groupNode) chain(faces []float64, exploded *distribution, b *budget) (*distribution, error) {
+	min, max := math.MaxInt64, math.MinInt64
+	for i := range faces {
+		c := g.contribution(g.min() + i)
+		low, high := c, c
+		if exploded != nil && g.explode.matches(g.min()+i) {
+			low, high = c+exploded.min, c+exploded.max()
+		}
+		if low < min {
+			min = low
+		}
+		if high > max {
+			max = high
+		}
+	}
+	res, err := newDistribution(min, max)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range faces {
+		c := g.contribution(g.min() + i)
+		if exploded != nil && g.explode.matches(g.min()+i) {
+			if err := b.spend(len(exploded.probs)); err != nil {
+				return nil, err
+			}
+			exploded.addTo(res, c, p)
+		} else {
+			res.probs[c-min] += p
+		}
+	}
+	return res, nil
+}
+
+// selectedDistribution computes the distribution of the kept dice by dynamic programming: the faces are taken
+// from the first kept to the last, and the state is the number of dice showing the faces taken so far with
+// the contribution of the kept ones.
+func (g *groupNode) selectedDistribution(faces []float64, b *budget) (*distribution, error) {
+	if g.explode != nil {
+		// The exploded dice are kept or dropped on their own.
+		return nil, errTooComplex
+	}
+	n := g.count
+	selected := g.selected
+	if selected > n {
+		selected = n
+	}
+	kept, highest := selected, true
+	switch g.selection {
+	case "kl":
+		highest = false
+	case "dh":
+		kept, highest = n-selected, false
+	case "dl":
+		kept = n - selected
+	}
+	if kept == 0 {
+		return point(0), nil
+	}
+	low, high := 0, 0
+	for v := g.min(); v <= g.max(); v++ {
+		c := g.contribution(v)
+		if c < low {
+			low = c
+		}
+		if c > high {
+			high = c
+		}
+	}
+	if err := b.spend(g.sides * (n + 1) * (n + 1) * ((high-low)*kept + 1)); err != nil {
+		return nil, err
+	}
+	binomials := binomialCoefficients(n)
+
+	// dp[i] is the distribution of the contribution of the kept dice, when i dice show the faces taken so far.
+	dp := make([]map[int]float64, n+1)
+	for i := range dp {
+		dp[i] = make(map[int]float64)
+	}
+	dp[0][0] = 1
+	for f := 0; f < g.sides; f++ {
+		v := g.min() + f
+		if highest {
+			v = g.max() - f
+		}
+		p := faces[v-g.min()]
+		if p == 0 {
+			continue
+		}
+		c := g.contribution(v)
+		next := make([]map[int]float64, n+1)
+		for i := range next {
+			next[i] = make(map[int]float64)
+		}
+		for i := 0; i <= n; i++ {
+			for s, q := range dp[i] {
+				weight := q
+				for j := 0; i+j <= n; j++ {
+					keptHere := kept - i
+					if keptHere < 0 {
+						keptHere = 0
+					}
+					if keptHere > j {
+						keptHere = j
+					}
+					next[i+j][s+keptHere*c] += weight * binomials[n-i][j]
+					weight *= p
+				}
+			}
+		}
+		dp = next
+	}
+	min, max := math.MaxInt64, math.MinInt64
+	for s := range dp[n] {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+	res, err := newDistribution(min, max)
+	if err != nil {
+		return nil, err
+	}
+	for s, p := range dp[n] {
+		res.probs[s-min] = p
+	}
+	return res, nil
+}
+
+func binomialCoefficients(n int) [][]float64 {
+	res := make([][]float64, n+1)
+	for i := range res {
+		res[i] = make([]float64, i+1)
+		res[i][0], res[i][i] = 1, 1
+		for j := 1; j < i; j++ {
+			res[i][j] = res[i-1][j-1] + res[i-1][j]
+		}
+	}
+	return res
+}
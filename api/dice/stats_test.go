@@ -0,0 +1,260 @@
+package dice
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func exactStats(t *testing.T, source string) *Stats {
+	e, err := Parse(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := e.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Exact {
+		t.Fatalf("the stats of %s must be exact", source)
+	}
+	return s
+}
+
+// checkDistribution compares the histogram to the expected probabilities, and checks its sums and moments.
+func checkDistribution(t *testing.T, s *Stats, expected map[int]float64) {
+	if len(s.Histogram) != len(expected) {
+		t.Fatalf("expected %d totals, got %d", len(expected), len(s.Histogram))
+	}
+	mean, cumulated := 0.0, 0.0
+	for _, o := range s.Histogram {
+		p, ok := expected[o.Total]
+		if !ok {
+			t.Fatalf("unexpected total %d", o.Total)
+		}
+		if math.Abs(o.Probability-p) > epsilon {
+			t.Errorf("total %d: expected %v, got %v", o.Total, p, o.Probability)
+		}
+		if math.Abs(o.AtLeast-(1-cumulated)) > epsilon {
+			t.Errorf("total %d: expected at least %v, got %v", o.Total, 1-cumulated, o.AtLeast)
+		}
+		cumulated += p
+		if math.Abs(o.AtMost-cumulated) > epsilon {
+			t.Errorf("total %d: expected at most %v, got %v", o.Total, cumulated, o.AtMost)
+		}
+		mean += float64(o.Total) * p
+	}
+	if math.Abs(cumulated-1) > epsilon {
+		t.Errorf("the probabilities sum to %v", cumulated)
+	}
+	if math.Abs(s.Mean-mean) > epsilon {
+		t.Errorf("expected mean %v, got %v", mean, s.Mean)
+	}
+}
+
+func TestStatsTwoDice(t *testing.T) {
+	s := exactStats(t, "2d6")
+	expected := map[int]float64{}
+	for total := 2; total <= 12; total++ {
+		expected[total] = float64(6-abs(total-7)) / 36
+	}
+	checkDistribution(t, s, expected)
+	if s.Min != 2 || s.Max != 12 {
+		t.Errorf("expected totals from 2 to 12, got %d to %d", s.Min, s.Max)
+	}
+	if math.Abs(s.Mean-7) > epsilon || math.Abs(s.StdDev-math.Sqrt(35.0/6)) > epsilon {
+		t.Errorf("expected mean 7 and deviation %v, got %v and %v", math.Sqrt(35.0/6), s.Mean, s.StdDev)
+	}
+}
+
+func TestStatsKeepHighest(t *testing.T) {
+	// Ways to roll each total from 3 with 4d6, keeping the 3 highest, among 6^4.
+	ways := []int{1, 4, 10, 21, 38, 62, 91, 122, 148, 167, 172, 160, 131, 94, 54, 21}
+	expected := map[int]float64{}
+	for i, w := range ways {
+		expected[3+i] = float64(w) / 1296
+	}
+	checkDistribution(t, exactStats(t, "4d6kh3"), expected)
+	// Dropping the lowest die is the same roll.
+	checkDistribution(t, exactStats(t, "4d6dl1"), expected)
+
+	// The lowest of 2d20, the disadvantage: P(v) = ((21-v)^2 - (20-v)^2) / 400.
+	expected = map[int]float64{}
+	for v := 1; v <= 20; v++ {
+		expected[v] = float64((21-v)*(21-v)-(20-v)*(20-v)) / 400
+	}
+	checkDistribution(t, exactStats(t, "2d20kl1"), expected)
+	checkDistribution(t, exactStats(t, "2d20dh1"), expected)
+}
+
+func TestStatsReroll(t *testing.T) {
+	// Rerolled once: a 1 is kept only when rolled twice.
+	expected := map[int]float64{1: 1.0 / 400}
+	for v := 2; v <= 20; v++ {
+		expected[v] = 1.0/20 + 1.0/400
+	}
+	checkDistribution(t, exactStats(t, "1d20ro"), expected)
+
+	// Rerolled until it is not a 1.
+	expected = map[int]float64{}
+	for v := 2; v <= 20; v++ {
+		expected[v] = 1.0 / 19
+	}
+	checkDistribution(t, exactStats(t, "1d20r"), expected)
+
+	// Rerolled once on 1 or 2, then the 2 highest of 4 dice are kept.
+	s := exactStats(t, "4d6ro<3kh2")
+	if s.Min != 2 || s.Max != 12 {
+		t.Errorf("expected totals from 2 to 12, got %d to %d", s.Min, s.Max)
+	}
+}
+
+func TestStatsExplode(t *testing.T) {
+	// A total 6m + r, with r from 1 to 5, is rolled with m sixes then r: P = (1/6)^(m+1).
+	s := exactStats(t, "1d6!")
+	for _, o := range s.Histogram {
+		m, r := o.Total/6, o.Total%6
+		expected := 0.0
+		if r != 0 {
+			expected = math.Pow(1.0/6, float64(m+1))
+		}
+		if math.Abs(o.Probability-expected) > epsilon {
+			t.Errorf("total %d: expected %v, got %v", o.Total, expected, o.Probability)
+		}
+	}
+	// The mean of a die exploding with probability q is its mean over 1-q.
+	if math.Abs(s.Mean-3.5/(1-1.0/6)) > 1e-6 {
+		t.Errorf("expected mean %v, got %v", 3.5/(1-1.0/6), s.Mean)
+	}
+	if s.AtLeast(6) <= 1.0/6-epsilon || s.AtLeast(6) >= 1.0/6+epsilon {
+		t.Errorf("expected to explode with probability 1/6, got %v", s.AtLeast(6))
+	}
+
+	s = exactStats(t, "1d6!>=5")
+	if math.Abs(s.Mean-3.5/(1-2.0/6)) > 1e-6 {
+		t.Errorf("expected mean %v, got %v", 3.5/(1-2.0/6), s.Mean)
+	}
+	// Each die exploding on 10 succeeds on 8 and more, the successes of a die are geometric.
+	s = exactStats(t, "1d10>=8!")
+	if math.Abs(s.Mean-0.3/(1-0.1)) > 1e-6 {
+		t.Errorf("expected mean %v, got %v", 0.3/0.9, s.Mean)
+	}
+}
+
+func TestStatsSuccesses(t *testing.T) {
+	// Binomial successes of 5 dice succeeding with 3 faces out of 10.
+	expected := map[int]float64{}
+	for k := 0; k <= 5; k++ {
+		expected[k] = binomial(5, k) * math.Pow(0.3, float64(k)) * math.Pow(0.7, float64(5-k))
+	}
+	checkDistribution(t, exactStats(t, "5d10>=8"), expected)
+
+	// Each die adds 1 on 8 and more, and subtracts 1 on a 1.
+	s := exactStats(t, "2d10>=8f1")
+	die := map[int]float64{-1: 0.1, 0: 0.6, 1: 0.3}
+	expected = map[int]float64{}
+	for a, p := range die {
+		for b, q := range die {
+			expected[a+b] += p * q
+		}
+	}
+	checkDistribution(t, s, expected)
+
+	// Fate dice: ways to roll each total from -4 among 3^4.
+	ways := []int{1, 4, 10, 16, 19, 16, 10, 4, 1}
+	expected = map[int]float64{}
+	for i, w := range ways {
+		expected[i-4] = float64(w) / 81
+	}
+	checkDistribution(t, exactStats(t, "4dF"), expected)
+}
+
+func TestStatsArithmetic(t *testing.T) {
+	expected := map[int]float64{}
+	for v := 1; v <= 6; v++ {
+		expected[2*v+3] = 1.0 / 6
+	}
+	checkDistribution(t, exactStats(t, "1d6 * 2 + 3"), expected)
+
+	expected = map[int]float64{}
+	for a := 1; a <= 4; a++ {
+		for b := 1; b <= 4; b++ {
+			expected[a-b] += 1.0 / 16
+		}
+	}
+	checkDistribution(t, exactStats(t, "1d4 - 1d4"), expected)
+	checkDistribution(t, exactStats(t, "-1d4 + 1d4"), expected)
+
+	e, err := Parse("1d6 / (1d6 - 1d6)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Stats(); err == nil {
+		t.Error("a division by zero must be rejected")
+	}
+}
+
+func TestStatsOdds(t *testing.T) {
+	s := exactStats(t, "2d6")
+	tests := []struct {
+		total   int
+		atLeast float64
+		atMost  float64
+	}{
+		{-5, 1, 0},
+		{2, 1, 1.0 / 36},
+		{7, 21.0 / 36, 21.0 / 36},
+		{12, 1.0 / 36, 1},
+		{13, 0, 1},
+	}
+	for _, test := range tests {
+		if v := s.AtLeast(test.total); math.Abs(v-test.atLeast) > epsilon {
+			t.Errorf("at least %d: expected %v, got %v", test.total, test.atLeast, v)
+		}
+		if v := s.AtMost(test.total); math.Abs(v-test.atMost) > epsilon {
+			t.Errorf("at most %d: expected %v, got %v", test.total, test.atMost, v)
+		}
+	}
+}
+
+func TestStatsSimulated(t *testing.T) {
+	// The exploded dice are kept or dropped on their own, the distribution is simulated.
+	e, err := Parse("4d6!kh3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := e.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Exact || s.Samples != simulatedRolls {
+		t.Errorf("expected %d simulated rolls, got exact %v with %d samples", simulatedRolls, s.Exact, s.Samples)
+	}
+	total := 0.0
+	for _, o := range s.Histogram {
+		total += o.Probability
+	}
+	if math.Abs(total-1) > epsilon {
+		t.Errorf("the probabilities sum to %v", total)
+	}
+	// About 12.84, above the 12.24 of 4d6kh3, the standard error of the simulated mean is about 0.02.
+	if s.Min < 3 || s.Mean < 12.5 || s.Mean > 14.5 {
+		t.Errorf("unexpected simulated stats, min %d and mean %v", s.Min, s.Mean)
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func binomial(n, k int) float64 {
+	res := 1.0
+	for i := 1; i <= k; i++ {
+		res = res * float64(n-k+i) / float64(i)
+	}
+	return res
+}
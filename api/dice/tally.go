@@ -0,0 +1,201 @@
+package dice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// The chi-square test needs at least this count expected for each face.
+	minExpectedByFace = 5
+	// Dice failing the test at this significance level are reported as unfair.
+	fairnessSignificance = 0.01
+)
+
+// Fairness is the chi-square goodness of fit test of the faces rolled against uniform dice.
+type Fairness struct {
+	ChiSquare        float64 `json:"chiSquare"`
+	DegreesOfFreedom int     `json:"degreesOfFreedom"`
+	// Probability to get faces at least this uneven with fair dice.
+	PValue float64 `json:"pValue"`
+	Fair   bool    `json:"fair"`
+}
+
+// DieStats sums up the dice of a size, in the order they were rolled.
+type DieStats struct {
+	// Die like `d20`, or `dF` for the Fate dice.
+	Die   string `json:"die"`
+	Sides int    `json:"sides"`
+	Fate  bool   `json:"fate"`
+	Count int    `json:"count"`
+	// Count of each face, from the lowest.
+	Faces    []int   `json:"faces"`
+	Average  float64 `json:"average"`
+	Expected float64 `json:"expected"`
+	// Natural maximums and minimums, like the 20s and the 1s of a d20.
+	Maximums int `json:"maximums"`
+	Minimums int `json:"minimums"`
+	// Longest runs of dice above and below the expected value.
+	HotStreak  int `json:"hotStreak"`
+	ColdStreak int `json:"coldStreak"`
+	// Missing until enough dice are rolled.
+	Fairness *Fairness `json:"fairness"`
+
+	sum       int
+	hot, cold int
+}
+
+func (s *DieStats) min() int {
+	if s.Fate {
+		return -1
+	}
+	return 1
+}
+
+func (s *DieStats) add(v int) {
+	s.Count++
+	s.sum += v
+	s.Faces[v-s.min()]++
+	s.Average = float64(s.sum) / float64(s.Count)
+	if v == s.min()+s.Sides-1 {
+		s.Maximums++
+	}
+	if v == s.min() {
+		s.Minimums++
+	}
+	switch {
+	case float64(v) > s.Expected:
+		s.hot, s.cold = s.hot+1, 0
+	case float64(v) < s.Expected:
+		s.hot, s.cold = 0, s.cold+1
+	default:
+		s.hot, s.cold = 0, 0
+	}
+	if s.hot > s.HotStreak {
+		s.HotStreak = s.hot
+	}
+	if s.cold > s.ColdStreak {
+		s.ColdStreak = s.cold
+	}
+}
+
+// Tally sums up the dice of rolls by size.
+type Tally struct {
+	Rolls int         `json:"rolls"`
+	Dice  []*DieStats `json:"dice"`
+}
+
+func NewTally() *Tally {
+	return &Tally{Dice: []*DieStats{}}
+}
+
+// Add counts the dice of the roll, the rerolled, exploded and dropped ones included.
+func (t *Tally) Add(r *Result) {
+	t.Rolls++
+	for _, g := range r.Groups {
+		s := t.die(g.Sides, g.Fate)
+		for _, d := range g.Dice {
+			s.add(d.Value)
+		}
+	}
+}
+
+func (t *Tally) die(sides int, fate bool) *DieStats {
+	for _, s := range t.Dice {
+		if s.Sides == sides && s.Fate == fate {
+			return s
+		}
+	}
+	s := &DieStats{Die: fmt.Sprintf("d%d", sides), Sides: sides, Fate: fate, Faces: make([]int, sides), Expected: float64(sides+1) / 2}
+	if fate {
+		s.Die, s.Expected = "dF", 0
+	}
+	t.Dice = append(t.Dice, s)
+	sort.Slice(t.Dice, func(i, j int) bool {
+		if t.Dice[i].Sides != t.Dice[j].Sides {
+			return t.Dice[i].Sides < t.Dice[j].Sides
+		}
+		return !t.Dice[i].Fate
+	})
+	return s
+}
+
+// Summarize runs the fairness tests, once every roll is added.
+func (t *Tally) Summarize() {
+	for _, s := range t.Dice {
+		s.Fairness = TestFairness(s.Faces)
+	}
+}
+
+// TestFairness runs the chi-square test of the counts of the faces against uniform dice,
+// nil when there are too few dice for the test.
+func TestFairness(faces []int) *Fairness {
+	total := 0
+	for _, c := range faces {
+		total += c
+	}
+	if len(faces) < 2 {
+		return nil
+	}
+	expected := float64(total) / float64(len(faces))
+	if expected < minExpectedByFace {
+		return nil
+	}
+	res := &Fairness{DegreesOfFreedom: len(faces) - 1}
+	for _, c := range faces {
+		res.ChiSquare += math.Pow(float64(c)-expected, 2) / expected
+	}
+	res.PValue = upperRegularizedGamma(float64(res.DegreesOfFreedom)/2, res.ChiSquare/2)
+	res.Fair = res.PValue >= fairnessSignificance
+	return res
+}
+
+// upperRegularizedGamma is Q(a, x), by its series when x < a+1 and by its continued fraction otherwise.
+func upperRegularizedGamma(a float64, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+	const (
+		iterations = 1000
+		epsilon    = 1e-14
+		tiny       = 1e-300
+	)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < iterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+	// Modified Lentz's method.
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < iterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return prefix * h
+}
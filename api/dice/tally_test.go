@@ -0,0 +1,127 @@
+package dice
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpperRegularizedGamma(t *testing.T) {
+	// Quantiles of the chi-square distribution: the p-value of x with k degrees of freedom is Q(k/2, x/2).
+	tests := []struct {
+		degrees int
+		x       float64
+		pValue  float64
+	}{
+		{1, 3.841458820694124, 0.05},
+		{1, 6.634896601021214, 0.01},
+		{5, 11.070497693516351, 0.05},
+		{5, 15.086272469388989, 0.01},
+		{10, 2.558212160940830, 0.99},
+		{10, 18.307038053275146, 0.05},
+		{19, 30.143527205646159, 0.05},
+		{19, 36.190869129270048, 0.01},
+		{99, 123.22522145336181, 0.05},
+		// With 2 degrees of freedom the p-value is exp(-x/2).
+		{2, 4, math.Exp(-2)},
+		{2, 0.5, math.Exp(-0.25)},
+		{2, 0, 1},
+	}
+	for _, test := range tests {
+		p := upperRegularizedGamma(float64(test.degrees)/2, test.x/2)
+		if math.Abs(p-test.pValue) > 1e-6 {
+			t.Errorf("chi-square %v with %d degrees of freedom: expected %v, got %v", test.x, test.degrees, test.pValue, p)
+		}
+	}
+}
+
+func TestChiSquareFairness(t *testing.T) {
+	tests := []struct {
+		name      string
+		faces     []int
+		chiSquare float64
+		fair      bool
+	}{
+		{"uniform", []int{10, 10, 10, 10, 10, 10}, 0, true},
+		{"close to uniform", []int{9, 11, 10, 12, 8, 10}, 1, true},
+		// Q(2.5, 12.5) is about 0.00014.
+		{"loaded", []int{30, 10, 10, 10, 10, 10}, 25, false},
+		// Fate dice: expected 10 by face, chi-square 0.8 with 2 degrees of freedom, p-value exp(-0.4).
+		{"fate", []int{12, 10, 8}, 0.8, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := TestFairness(test.faces)
+			if f == nil {
+				t.Fatal("expected a fairness test")
+			}
+			if f.DegreesOfFreedom != len(test.faces)-1 {
+				t.Errorf("expected %d degrees of freedom, got %d", len(test.faces)-1, f.DegreesOfFreedom)
+			}
+			if math.Abs(f.ChiSquare-test.chiSquare) > 1e-9 {
+				t.Errorf("expected chi-square %v, got %v", test.chiSquare, f.ChiSquare)
+			}
+			if p := upperRegularizedGamma(float64(f.DegreesOfFreedom)/2, f.ChiSquare/2); f.PValue != p {
+				t.Errorf("expected p-value %v, got %v", p, f.PValue)
+			}
+			if f.Fair != test.fair {
+				t.Errorf("expected fair %v, got %v with p-value %v", test.fair, f.Fair, f.PValue)
+			}
+		})
+	}
+
+	// Too few dice for the test.
+	for _, faces := range [][]int{{4, 5, 6, 5, 4, 5}, {100}, {}} {
+		if f := TestFairness(faces); f != nil {
+			t.Errorf("expected no test for %v, got %+v", faces, f)
+		}
+	}
+}
+
+func TestTally(t *testing.T) {
+	tally := NewTally()
+	tally.Add(&Result{Groups: []Group{
+		{Sides: 20, Dice: []Die{{Value: 20}, {Value: 15}, {Value: 11}}},
+		{Sides: 3, Fate: true, Dice: []Die{{Value: -1}, {Value: 1}}},
+	}})
+	tally.Add(&Result{Groups: []Group{
+		// The dropped and rerolled dice are counted too.
+		{Sides: 20, Dice: []Die{{Value: 1, Dropped: true, Rerolled: true}, {Value: 3}, {Value: 10}, {Value: 12}}},
+		{Sides: 6, Dice: []Die{{Value: 6, Exploded: true}, {Value: 2}}},
+	}})
+	tally.Summarize()
+
+	if tally.Rolls != 2 {
+		t.Errorf("expected 2 rolls, got %d", tally.Rolls)
+	}
+	// By size, the Fate dice having 3 sides.
+	if len(tally.Dice) != 3 || tally.Dice[0].Die != "dF" || tally.Dice[1].Die != "d6" || tally.Dice[2].Die != "d20" {
+		t.Fatalf("unexpected dice %+v", tally.Dice)
+	}
+
+	d20 := tally.Dice[2]
+	if d20.Count != 7 || d20.Maximums != 1 || d20.Minimums != 1 || d20.Expected != 10.5 {
+		t.Errorf("unexpected d20 stats %+v", d20)
+	}
+	if math.Abs(d20.Average-72.0/7) > 1e-9 {
+		t.Errorf("expected average %v, got %v", 72.0/7, d20.Average)
+	}
+	// 20, 15, 11 are above 10.5, then 1, 3, 10 below.
+	if d20.HotStreak != 3 || d20.ColdStreak != 3 {
+		t.Errorf("expected streaks of 3, got %d hot and %d cold", d20.HotStreak, d20.ColdStreak)
+	}
+	if d20.Faces[0] != 1 || d20.Faces[19] != 1 || d20.Faces[14] != 1 {
+		t.Errorf("unexpected faces %v", d20.Faces)
+	}
+	if d20.Fairness != nil {
+		t.Error("7 dice are too few for the fairness test")
+	}
+
+	dF := tally.Dice[0]
+	if dF.Count != 2 || dF.Expected != 0 || dF.Faces[0] != 1 || dF.Faces[2] != 1 || dF.Maximums != 1 || dF.Minimums != 1 {
+		t.Errorf("unexpected Fate dice stats %+v", dF)
+	}
+	// -1 then 1, each streak lasts a single die.
+	if dF.HotStreak != 1 || dF.ColdStreak != 1 {
+		t.Errorf("expected streaks of 1, got %d hot and %d cold", dF.HotStreak, dF.ColdStreak)
+	}
+}
@@ -0,0 +1,61 @@
+package game_system
+
+// Sheet of a generic percentile system, characteristics and skills are rolled under with a d100.
+const d100Sheet = `{
+  "type": "object",
+  "required": ["characteristics", "hitPoints"],
+  "properties": {
+    "occupation": {"type": "string", "maxLength": 100},
+    "characteristics": {
+      "type": "object",
+      "required": ["str", "con", "siz", "dex", "app", "int", "pow", "edu"],
+      "additionalProperties": false,
+      "properties": {
+        "str": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "con": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "siz": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "dex": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "app": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "int": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "pow": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+        "edu": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50}
+      }
+    },
+    "hitPoints": {
+      "type": "object",
+      "required": ["max", "current"],
+      "properties": {
+        "max": {"type": "integer", "minimum": 1, "default": 10},
+        "current": {"type": "integer", "default": 10}
+      }
+    },
+    "sanity": {"type": "integer", "minimum": 0, "maximum": 99, "default": 50},
+    "luck": {"type": "integer", "minimum": 0, "maximum": 100, "default": 50},
+    "skills": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name", "value"],
+        "properties": {
+          "name": {"type": "string", "minLength": 1},
+          "value": {"type": "integer", "minimum": 0, "maximum": 100}
+        }
+      }
+    }
+  }
+}`
+
+func init() {
+	mustRegister(&GameSystem{
+		Id:          "d100",
+		Name:        "Generic d100",
+		Description: "Percentile system, characteristics and skills are rolled under with a d100.",
+		Sheet:       mustSchema(d100Sheet),
+		Derived: map[string]string{
+			"maxHitPoints": "floor((characteristics.con + characteristics.siz) / 10)",
+			"magicPoints":  "floor(characteristics.pow / 5)",
+			"moveRate": "if(characteristics.dex < characteristics.siz && characteristics.str < characteristics.siz, 7, " +
+				"if(characteristics.dex > characteristics.siz && characteristics.str > characteristics.siz, 9, 8))",
+		},
+	})
+}
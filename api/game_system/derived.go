@@ -0,0 +1,150 @@
+package game_system
+
+import (
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var derivedNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// compile parses the formulas of the derived fields and orders them by dependency.
+func (s *GameSystem) compile() error {
+	s.formulas = make(map[string]*Formula, len(s.Derived))
+	for name, source := range s.Derived {
+		// Names are stored as keys of documents and read as variables of formulas.
+		if !derivedNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid derived field name %q", name)
+		}
+		f, err := ParseFormula(source)
+		if err != nil {
+			return fmt.Errorf("derived field %s: %v", name, err)
+		}
+		s.formulas[name] = f
+	}
+
+	// Depth first, sorted for the order to be stable.
+	names := make([]string, 0, len(s.formulas))
+	for name := range s.formulas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(names))
+	s.order = make([]string, 0, len(names))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch states[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("cycle between derived fields %s -> %s", strings.Join(path, " -> "), name)
+		}
+		states[name] = visiting
+		for _, v := range s.formulas[name].Variables {
+			if _, ok := s.formulas[v]; ok {
+				if err := visit(v, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		states[name] = visited
+		s.order = append(s.order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Derive computes the derived fields of a sheet, in dependency order.
+func (s *GameSystem) Derive(sheet map[string]interface{}) (map[string]float64, []lib.FieldError) {
+	res := make(map[string]float64, len(s.order))
+	scope := func(path string, count bool) (float64, error) {
+		if v, ok := res[path]; ok && !count {
+			return v, nil
+		}
+		value, found := sheetValue(sheet, path)
+		if count {
+			if !found || value == nil {
+				return 0, nil
+			}
+			if items, ok := value.([]interface{}); ok {
+				return float64(len(items)), nil
+			}
+			return 0, fmt.Errorf("%s is not an array", path)
+		}
+		if v, ok := numberValue(value); ok {
+			return v, nil
+		}
+		if !found {
+			return 0, fmt.Errorf("%s is missing", path)
+		}
+		return 0, fmt.Errorf("%s is not a number", path)
+	}
+	errs := make([]lib.FieldError, 0)
+	for _, name := range s.order {
+		v, err := s.formulas[name].Eval(scope)
+		if err != nil {
+			errs = append(errs, lib.FieldError{Field: "/derived/" + name, Message: err.Error()})
+			continue
+		}
+		res[name] = v
+	}
+	return res, errs
+}
+
+// sheetValue reads the value of a dotted path of the sheet.
+func sheetValue(sheet map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = sheet
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// numberValue reads a number of a sheet, booleans are 1 or 0 as in the formulas.
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case bool:
+		return fromBool(v), true
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Field reads a derived field by its name, or else a number of the sheet by its dotted path.
+func Field(sheet map[string]interface{}, derived map[string]float64, path string) (float64, error) {
+	if v, ok := derived[path]; ok {
+		return v, nil
+	}
+	value, found := sheetValue(sheet, path)
+	if !found {
+		return 0, fmt.Errorf("%s is missing", path)
+	}
+	if v, ok := numberValue(value); ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%s is not a number", path)
+}
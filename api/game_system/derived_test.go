@@ -0,0 +1,143 @@
+package game_system
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// registerTest registers a game system for the test only.
+func registerTest(t *testing.T, system *GameSystem) error {
+	if err := Register(system); err != nil {
+		return err
+	}
+	t.Cleanup(func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		delete(systems, system.Id)
+	})
+	return nil
+}
+
+func TestDerivedOrder(t *testing.T) {
+	system := &GameSystem{Id: "test-order", Derived: map[string]string{
+		"total":      "bonus + half",
+		"half":       "double / 4",
+		"double":     "base * 2",
+		"bonus":      "1",
+		"standalone": "base",
+	}}
+	if err := registerTest(t, system); err != nil {
+		t.Fatal(err)
+	}
+	// Dependencies first, the others by name.
+	if expected := []string{"bonus", "double", "half", "standalone", "total"}; !reflect.DeepEqual(system.order, expected) {
+		t.Errorf("expected %v, got %v", expected, system.order)
+	}
+	derived, errs := system.Derive(map[string]interface{}{"base": 6.0})
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	expected := map[string]float64{"bonus": 1, "double": 12, "half": 3, "standalone": 6, "total": 4}
+	if !reflect.DeepEqual(derived, expected) {
+		t.Errorf("expected %v, got %v", expected, derived)
+	}
+}
+
+func TestRegisterRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		derived map[string]string
+		message string
+	}{
+		{"self cycle", map[string]string{"a": "a + 1"}, "cycle"},
+		{"cycle", map[string]string{"a": "b + 1", "b": "a"}, "cycle"},
+		{"long cycle", map[string]string{"a": "b", "b": "c", "c": "if(1, 0, a)", "d": "a"}, "cycle"},
+		{"cycle through count", map[string]string{"a": "count(b)", "b": "a"}, "cycle"},
+		{"invalid name", map[string]string{"a.b": "1"}, "invalid derived field name"},
+		{"name starting with a digit", map[string]string{"1a": "1"}, "invalid derived field name"},
+		{"invalid formula", map[string]string{"a": "1 +"}, "derived field a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			system := &GameSystem{Id: "test-rejected", Derived: test.derived}
+			err := registerTest(t, system)
+			if err == nil || !strings.Contains(err.Error(), test.message) {
+				t.Errorf("expected an error with %q, got %v", test.message, err)
+			}
+			if ById(system.Id) != nil {
+				t.Error("a rejected game system must not be registered")
+			}
+		})
+	}
+}
+
+func TestDeriveErrors(t *testing.T) {
+	system := &GameSystem{Id: "test-errors", Derived: map[string]string{
+		"missing":   "nothing + 1",
+		"dependent": "missing * 2",
+		"notNumber": "name",
+		"notArray":  "count(name)",
+		"divided":   "1 / zero",
+		"counted":   "count(items) + count(absent)",
+		"boolean":   "flag + 1",
+	}}
+	if err := registerTest(t, system); err != nil {
+		t.Fatal(err)
+	}
+	sheet := map[string]interface{}{"name": "Alice", "zero": 0.0, "items": []interface{}{"rope", "torch"}, "flag": true}
+	derived, errs := system.Derive(sheet)
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	// Reported in dependency order, a field depending on a failed one fails too.
+	expected := []string{"/derived/missing", "/derived/dependent", "/derived/divided", "/derived/notArray", "/derived/notNumber"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected errors on %v, got %v", expected, errs)
+	}
+	if derived["counted"] != 2 || derived["boolean"] != 2 {
+		t.Errorf("unexpected derived fields %v", derived)
+	}
+}
+
+func TestBuiltInDerived(t *testing.T) {
+	sheet := map[string]interface{}{
+		"class":      "rogue",
+		"level":      5.0,
+		"abilities":  map[string]interface{}{"str": 8.0, "dex": 17.0, "con": 12.0, "int": 10.0, "wis": 13.0, "cha": 9.0},
+		"hitPoints":  map[string]interface{}{"max": 30.0, "current": 30.0},
+		"armorClass": 15.0,
+	}
+	_, derived, err := ValidateSheet("dnd5e-srd", sheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{
+		"strModifier":       -1,
+		"dexModifier":       3,
+		"conModifier":       1,
+		"intModifier":       0,
+		"wisModifier":       1,
+		"chaModifier":       -1,
+		"proficiencyBonus":  3,
+		"initiative":        3,
+		"passivePerception": 11,
+		"carryingCapacity":  120,
+	}
+	if !reflect.DeepEqual(derived, expected) {
+		t.Errorf("expected %v, got %v", expected, derived)
+	}
+	if v, err := Field(sheet, derived, "initiative"); err != nil || v != 3 {
+		t.Errorf("expected the derived initiative, got %v %v", v, err)
+	}
+	if v, err := Field(sheet, derived, "abilities.dex"); err != nil || v != 17 {
+		t.Errorf("expected the dexterity of the sheet, got %v %v", v, err)
+	}
+	if _, err := Field(sheet, derived, "class"); err == nil {
+		t.Error("a text field is not a number")
+	}
+	if _, err := Field(sheet, derived, "abilities.luck"); err == nil {
+		t.Error("a missing field must be rejected")
+	}
+}
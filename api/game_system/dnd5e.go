@@ -0,0 +1,65 @@
+package game_system
+
+// Sheet of the System Reference Document of the fifth edition of D&D.
+const dnd5eSheet = `{
+  "type": "object",
+  "required": ["class", "level", "abilities", "hitPoints", "armorClass"],
+  "properties": {
+    "class": {"type": "string", "enum": ["barbarian", "bard", "cleric", "druid", "fighter", "monk", "paladin", "ranger", "rogue", "sorcerer", "warlock", "wizard"], "default": "fighter"},
+    "level": {"type": "integer", "minimum": 1, "maximum": 20, "default": 1},
+    "race": {"type": "string", "enum": ["dragonborn", "dwarf", "elf", "gnome", "half-elf", "half-orc", "halfling", "human", "tiefling"]},
+    "background": {"type": "string", "maxLength": 100},
+    "alignment": {"type": "string", "enum": ["LG", "NG", "CG", "LN", "N", "CN", "LE", "NE", "CE"]},
+    "experience": {"type": "integer", "minimum": 0, "default": 0},
+    "abilities": {
+      "type": "object",
+      "required": ["str", "dex", "con", "int", "wis", "cha"],
+      "additionalProperties": false,
+      "properties": {
+        "str": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10},
+        "dex": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10},
+        "con": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10},
+        "int": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10},
+        "wis": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10},
+        "cha": {"type": "integer", "minimum": 1, "maximum": 30, "default": 10}
+      }
+    },
+    "hitPoints": {
+      "type": "object",
+      "required": ["max", "current"],
+      "properties": {
+        "max": {"type": "integer", "minimum": 1, "default": 10},
+        "current": {"type": "integer", "default": 10},
+        "temporary": {"type": "integer", "minimum": 0, "default": 0}
+      }
+    },
+    "armorClass": {"type": "integer", "minimum": 0, "default": 10},
+    "speed": {"type": "integer", "minimum": 0, "default": 30},
+    "proficiencies": {
+      "type": "array",
+      "items": {"type": "string", "enum": ["acrobatics", "animal-handling", "arcana", "athletics", "deception", "history", "insight", "intimidation", "investigation", "medicine", "nature", "perception", "performance", "persuasion", "religion", "sleight-of-hand", "stealth", "survival"]}
+    },
+    "inventory": {"type": "array", "items": {"type": "string"}}
+  }
+}`
+
+func init() {
+	mustRegister(&GameSystem{
+		Id:          "dnd5e-srd",
+		Name:        "D&D 5e SRD",
+		Description: "Fifth edition of Dungeons & Dragons, from its System Reference Document.",
+		Sheet:       mustSchema(dnd5eSheet),
+		Derived: map[string]string{
+			"strModifier":       "floor((abilities.str - 10) / 2)",
+			"dexModifier":       "floor((abilities.dex - 10) / 2)",
+			"conModifier":       "floor((abilities.con - 10) / 2)",
+			"intModifier":       "floor((abilities.int - 10) / 2)",
+			"wisModifier":       "floor((abilities.wis - 10) / 2)",
+			"chaModifier":       "floor((abilities.cha - 10) / 2)",
+			"proficiencyBonus":  "2 + floor((level - 1) / 4)",
+			"initiative":        "dexModifier",
+			"passivePerception": "10 + wisModifier",
+			"carryingCapacity":  "abilities.str * 15",
+		},
+	})
+}
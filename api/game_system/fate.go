@@ -0,0 +1,63 @@
+package game_system
+
+// Sheet of Fate Core.
+const fateSheet = `{
+  "type": "object",
+  "required": ["aspects", "skills", "refresh", "stress"],
+  "properties": {
+    "aspects": {
+      "type": "object",
+      "required": ["highConcept", "trouble"],
+      "properties": {
+        "highConcept": {"type": "string", "default": ""},
+        "trouble": {"type": "string", "default": ""},
+        "others": {"type": "array", "maxItems": 3, "items": {"type": "string"}}
+      }
+    },
+    "skills": {
+      "type": "array",
+      "default": [],
+      "items": {
+        "type": "object",
+        "required": ["name", "rating"],
+        "properties": {
+          "name": {"type": "string", "minLength": 1},
+          "rating": {"type": "integer", "minimum": 0, "maximum": 8}
+        }
+      }
+    },
+    "stunts": {"type": "array", "items": {"type": "string"}},
+    "refresh": {"type": "integer", "minimum": 0, "default": 3},
+    "fatePoints": {"type": "integer", "minimum": 0, "default": 3},
+    "stress": {
+      "type": "object",
+      "required": ["physical", "mental"],
+      "properties": {
+        "physical": {"type": "array", "maxItems": 6, "items": {"type": "boolean"}, "default": [false, false, false]},
+        "mental": {"type": "array", "maxItems": 6, "items": {"type": "boolean"}, "default": [false, false, false]}
+      }
+    },
+    "consequences": {
+      "type": "object",
+      "properties": {
+        "mild": {"type": "string"},
+        "moderate": {"type": "string"},
+        "severe": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func init() {
+	mustRegister(&GameSystem{
+		Id:          "fate-core",
+		Name:        "Fate Core",
+		Description: "Aspects, skills on the ladder, stunts and stress tracks, rolled with four Fate dice.",
+		Sheet:       mustSchema(fateSheet),
+		Derived: map[string]string{
+			// Three stunts are free, each other one costs a refresh.
+			"stuntsCost":       "max(0, count(stunts) - 3)",
+			"effectiveRefresh": "refresh - stuntsCost",
+		},
+	})
+}
@@ -0,0 +1,462 @@
+package game_system
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxFormulaLength = 512
+	maxFormulaDepth  = 32
+)
+
+// Formula is a safe arithmetic expression over the fields of a sheet, like `floor((abilities.str - 10) / 2)`.
+//
+// It supports numbers, dotted field paths, the operators + - * / % < <= > >= == != && || !,
+// the functions floor, ceil, round, abs, min, max and if(condition, then, else),
+// and count(path) returning the number of items of an array. Booleans are 1 and 0.
+type Formula struct {
+	source string
+	root   formulaNode
+	// Paths of the fields read by the formula.
+	Variables []string
+}
+
+// Scope resolves the paths of a formula, count tells whether the number of items of an array is asked.
+type Scope func(path string, count bool) (float64, error)
+
+func (f *Formula) String() string { return f.source }
+
+// Eval computes the formula.
+func (f *Formula) Eval(scope Scope) (float64, error) {
+	return f.root.eval(scope)
+}
+
+// ParseFormula compiles a formula, its syntax is checked once.
+func ParseFormula(source string) (*Formula, error) {
+	if len(source) > maxFormulaLength {
+		return nil, fmt.Errorf("formula longer than %d characters", maxFormulaLength)
+	}
+	tokens, err := tokenizeFormula(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &formulaParser{tokens: tokens, variables: map[string]bool{}, order: []string{}}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at %d", p.peek().text, p.peek().pos)
+	}
+	return &Formula{source: source, root: root, Variables: p.order}, nil
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type formulaToken struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+var formulaOperators = []string{"<=", ">=", "==", "!=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!"}
+
+func tokenizeFormula(source string) ([]formulaToken, error) {
+	res := make([]formulaToken, 0)
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			value, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", string(runes[start:i]), start)
+			}
+			res = append(res, formulaToken{kind: tokenNumber, text: string(runes[start:i]), value: value, pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			ident := string(runes[start:i])
+			if strings.HasSuffix(ident, ".") || strings.Contains(ident, "..") {
+				return nil, fmt.Errorf("invalid path %q at %d", ident, start)
+			}
+			res = append(res, formulaToken{kind: tokenIdent, text: ident, pos: start})
+		case r == '(':
+			res = append(res, formulaToken{kind: tokenOpen, text: "(", pos: i})
+			i++
+		case r == ')':
+			res = append(res, formulaToken{kind: tokenClose, text: ")", pos: i})
+			i++
+		case r == ',':
+			res = append(res, formulaToken{kind: tokenComma, text: ",", pos: i})
+			i++
+		default:
+			found := false
+			for _, op := range formulaOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					res = append(res, formulaToken{kind: tokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected character %q at %d", r, i)
+			}
+		}
+	}
+	return append(res, formulaToken{kind: tokenEnd, text: "end of formula", pos: len(runes)}), nil
+}
+
+// Parser, by precedence: || then && then comparisons then + - then * / % then unary operators.
+
+type formulaParser struct {
+	tokens    []formulaToken
+	pos       int
+	variables map[string]bool
+	order     []string
+}
+
+func (p *formulaParser) peek() formulaToken { return p.tokens[p.pos] }
+
+func (p *formulaParser) next() formulaToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *formulaParser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *formulaParser) binary(depth int, operand func(int) (formulaNode, error), ops ...string) (formulaNode, error) {
+	left, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(ops...) {
+		op := p.next().text
+		right, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) parseOr(depth int) (formulaNode, error) {
+	if depth > maxFormulaDepth {
+		return nil, fmt.Errorf("formula nested deeper than %d", maxFormulaDepth)
+	}
+	return p.binary(depth, p.parseAnd, "||")
+}
+
+func (p *formulaParser) parseAnd(depth int) (formulaNode, error) {
+	return p.binary(depth, p.parseComparison, "&&")
+}
+
+func (p *formulaParser) parseComparison(depth int) (formulaNode, error) {
+	return p.binary(depth, p.parseSum, "<", "<=", ">", ">=", "==", "!=")
+}
+
+func (p *formulaParser) parseSum(depth int) (formulaNode, error) {
+	return p.binary(depth, p.parseProduct, "+", "-")
+}
+
+func (p *formulaParser) parseProduct(depth int) (formulaNode, error) {
+	return p.binary(depth, p.parseUnary, "*", "/", "%")
+}
+
+func (p *formulaParser) parseUnary(depth int) (formulaNode, error) {
+	if p.isOperator("-", "!", "+") {
+		op := p.next().text
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *formulaParser) parsePrimary(depth int) (formulaNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return numberNode(t.value), nil
+	case tokenOpen:
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokenClose {
+			return nil, fmt.Errorf("expected ) at %d", c.pos)
+		}
+		return node, nil
+	case tokenIdent:
+		if p.peek().kind != tokenOpen {
+			p.variable(t.text)
+			return variableNode(t.text), nil
+		}
+		return p.parseCall(t, depth)
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+}
+
+func (p *formulaParser) parseCall(name formulaToken, depth int) (formulaNode, error) {
+	p.next()
+	fn, ok := formulaFunctions[name.text]
+	if !ok && name.text != "count" && name.text != "if" {
+		return nil, fmt.Errorf("unknown function %s at %d", name.text, name.pos)
+	}
+	args := make([]formulaNode, 0)
+	if p.peek().kind != tokenClose {
+		for {
+			if name.text == "count" {
+				arg := p.next()
+				if arg.kind != tokenIdent {
+					return nil, fmt.Errorf("count expects a path at %d", arg.pos)
+				}
+				p.variable(arg.text)
+				args = append(args, countNode(arg.text))
+			} else {
+				arg, err := p.parseOr(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+			}
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if c := p.next(); c.kind != tokenClose {
+		return nil, fmt.Errorf("expected ) at %d", c.pos)
+	}
+	if name.text == "count" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("count expects 1 argument")
+		}
+		return args[0], nil
+	}
+	if name.text == "if" {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("if expects 3 arguments")
+		}
+		return &ifNode{condition: args[0], then: args[1], otherwise: args[2]}, nil
+	}
+	if (fn.arity >= 0 && len(args) != fn.arity) || (fn.arity < 0 && len(args) == 0) {
+		return nil, fmt.Errorf("wrong number of arguments for %s", name.text)
+	}
+	return &callNode{name: name.text, fn: fn, args: args}, nil
+}
+
+func (p *formulaParser) variable(path string) {
+	if !p.variables[path] {
+		p.variables[path] = true
+		p.order = append(p.order, path)
+	}
+}
+
+// Evaluation
+
+type formulaNode interface {
+	eval(scope Scope) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(Scope) (float64, error) { return float64(n), nil }
+
+type variableNode string
+
+func (n variableNode) eval(scope Scope) (float64, error) { return scope(string(n), false) }
+
+type countNode string
+
+func (n countNode) eval(scope Scope) (float64, error) { return scope(string(n), true) }
+
+func toBool(v float64) bool { return v != 0 }
+
+func fromBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type unaryNode struct {
+	op      string
+	operand formulaNode
+}
+
+func (n *unaryNode) eval(scope Scope) (float64, error) {
+	v, err := n.operand.eval(scope)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "-":
+		return -v, nil
+	case "!":
+		return fromBool(!toBool(v)), nil
+	default:
+		return v, nil
+	}
+}
+
+type binaryNode struct {
+	op          string
+	left, right formulaNode
+}
+
+func (n *binaryNode) eval(scope Scope) (float64, error) {
+	l, err := n.left.eval(scope)
+	if err != nil {
+		return 0, err
+	}
+	// Short-circuit the boolean operators.
+	if n.op == "&&" && !toBool(l) {
+		return 0, nil
+	}
+	if n.op == "||" && toBool(l) {
+		return 1, nil
+	}
+	r, err := n.right.eval(scope)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return math.Mod(l, r), nil
+	case "<":
+		return fromBool(l < r), nil
+	case "<=":
+		return fromBool(l <= r), nil
+	case ">":
+		return fromBool(l > r), nil
+	case ">=":
+		return fromBool(l >= r), nil
+	case "==":
+		return fromBool(l == r), nil
+	case "!=":
+		return fromBool(l != r), nil
+	default:
+		return fromBool(toBool(r)), nil
+	}
+}
+
+type formulaFunction struct {
+	// Number of arguments, -1 for at least one.
+	arity int
+	call  func(args []float64) float64
+}
+
+var formulaFunctions = map[string]formulaFunction{
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"min": {-1, func(a []float64) float64 {
+		res := a[0]
+		for _, v := range a[1:] {
+			res = math.Min(res, v)
+		}
+		return res
+	}},
+	"max": {-1, func(a []float64) float64 {
+		res := a[0]
+		for _, v := range a[1:] {
+			res = math.Max(res, v)
+		}
+		return res
+	}},
+}
+
+type callNode struct {
+	name string
+	fn   formulaFunction
+	args []formulaNode
+}
+
+func (n *callNode) eval(scope Scope) (float64, error) {
+	values := make([]float64, len(n.args))
+	for idx, arg := range n.args {
+		v, err := arg.eval(scope)
+		if err != nil {
+			return 0, err
+		}
+		values[idx] = v
+	}
+	return n.fn.call(values), nil
+}
+
+// ifNode evaluates only the chosen branch.
+type ifNode struct {
+	condition, then, otherwise formulaNode
+}
+
+func (n *ifNode) eval(scope Scope) (float64, error) {
+	c, err := n.condition.eval(scope)
+	if err != nil {
+		return 0, err
+	}
+	if toBool(c) {
+		return n.then.eval(scope)
+	}
+	return n.otherwise.eval(scope)
+}
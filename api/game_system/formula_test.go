@@ -0,0 +1,203 @@
+package game_system
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mapScope resolves the paths from a map, the arrays are given by their number of items.
+func mapScope(values map[string]float64) Scope {
+	return func(path string, count bool) (float64, error) {
+		v, ok := values[path]
+		if !ok {
+			return 0, fmt.Errorf("%s is missing", path)
+		}
+		return v, nil
+	}
+}
+
+func TestTokenizeFormula(t *testing.T) {
+	tokens, err := tokenizeFormula("floor((abilities.str - 10) / 2) <= 1.5 && !x_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		kind tokenKind
+		text string
+	}{
+		{tokenIdent, "floor"}, {tokenOpen, "("}, {tokenOpen, "("}, {tokenIdent, "abilities.str"}, {tokenOperator, "-"},
+		{tokenNumber, "10"}, {tokenClose, ")"}, {tokenOperator, "/"}, {tokenNumber, "2"}, {tokenClose, ")"},
+		{tokenOperator, "<="}, {tokenNumber, "1.5"}, {tokenOperator, "&&"}, {tokenOperator, "!"}, {tokenIdent, "x_1"},
+		{tokenEnd, "end of formula"},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %+v", len(expected), tokens)
+	}
+	for i, e := range expected {
+		if tokens[i].kind != e.kind || tokens[i].text != e.text {
+			t.Errorf("token %d: expected %v %q, got %v %q", i, e.kind, e.text, tokens[i].kind, tokens[i].text)
+		}
+	}
+	if tokens[11].value != 1.5 {
+		t.Errorf("expected 1.5, got %v", tokens[11].value)
+	}
+	if tokens[3].pos != 7 {
+		t.Errorf("expected the path at 7, got %d", tokens[3].pos)
+	}
+
+	for _, source := range []string{"1..2", "1.2.3", "abilities.", "abilities..str", "a # b", "a & b", "a | b", "a = b"} {
+		t.Run(source, func(t *testing.T) {
+			if _, err := tokenizeFormula(source); err == nil {
+				t.Errorf("%q must be rejected", source)
+			}
+		})
+	}
+}
+
+func TestFormulaEval(t *testing.T) {
+	scope := mapScope(map[string]float64{"abilities.str": 8, "level": 5, "items": 3, "zero": 0})
+	tests := []struct {
+		source string
+		value  float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"10 / 4", 2.5},
+		{"2 * 3 % 4", 2},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"-2 * 3", -6},
+		{"- -2", 2},
+		{"+1", 1},
+		{"!0", 1},
+		{"!2", 0},
+		{"!!2", 1},
+		{"1 + 2 < 4", 1},
+		{"2 * 2 >= 5", 0},
+		{"1 < 2 == 1", 1},
+		{"3 != 3", 0},
+		{"1 || 0 && 0", 1},
+		{"0 && 1 || 1", 1},
+		{"1 < 2 && 2 < 1", 0},
+		{"floor(-1.5)", -2},
+		{"ceil(1.2)", 2},
+		{"round(2.5)", 3},
+		{"abs(-3)", 3},
+		{"min(3, 1, 2)", 1},
+		{"max(3)", 3},
+		{"max(1, level, 2)", 5},
+		{"if(1, 2, 3)", 2},
+		{"if(zero, 2, 3)", 3},
+		{"if(level > 4, 1, 0) + 1", 2},
+		{"count(items)", 3},
+		{"floor((abilities.str - 10) / 2)", -1},
+		{"2 + floor((level - 1) / 4)", 3},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			f, err := ParseFormula(test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := f.Eval(scope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != test.value {
+				t.Errorf("expected %v, got %v", test.value, v)
+			}
+		})
+	}
+}
+
+func TestFormulaShortCircuit(t *testing.T) {
+	scope := mapScope(map[string]float64{"one": 1})
+	tests := []struct {
+		source string
+		valid  bool
+	}{
+		{"0 && missing", true},
+		{"1 || missing", true},
+		{"0 && 1 / 0", true},
+		{"if(one, 2, missing)", true},
+		{"if(0, missing, 2)", true},
+		{"1 && missing", false},
+		{"0 || missing", false},
+		{"if(missing, 1, 2)", false},
+		{"min(1, missing)", false},
+		{"-missing", false},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			f, err := ParseFormula(test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = f.Eval(scope)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFormulaDivisionByZero(t *testing.T) {
+	scope := mapScope(map[string]float64{"x": 3})
+	for _, source := range []string{"1 / 0", "1 % 0", "1 / (x - x)", "x % (x - 3)", "floor(1 / 0)"} {
+		t.Run(source, func(t *testing.T) {
+			f, err := ParseFormula(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := f.Eval(scope); err == nil || !strings.Contains(err.Error(), "division by zero") {
+				t.Errorf("expected a division by zero, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseFormulaRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"empty", ""},
+		{"too long", strings.Repeat("1+", 256) + "1"},
+		{"too deep", strings.Repeat("(", 34) + "1" + strings.Repeat(")", 34)},
+		{"trailing operator", "1 +"},
+		{"missing operator", "1 2"},
+		{"unclosed parenthesis", "(1"},
+		{"unopened parenthesis", "1)"},
+		{"unknown function", "sqrt(4)"},
+		{"wrong arity", "floor(1, 2)"},
+		{"no argument", "min()"},
+		{"if without else", "if(1, 2)"},
+		{"count of a number", "count(1)"},
+		{"count of two paths", "count(a, b)"},
+		{"unclosed call", "max(1, 2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseFormula(test.source); err == nil {
+				t.Errorf("%q must be rejected", test.source)
+			}
+		})
+	}
+}
+
+func TestFormulaVariables(t *testing.T) {
+	f, err := ParseFormula("a + b.c * a + count(items) + floor(d)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b.c", "items", "d"}; !reflect.DeepEqual(f.Variables, expected) {
+		t.Errorf("expected %v, got %v", expected, f.Variables)
+	}
+}
@@ -0,0 +1,17 @@
+package game_system
+
+import "github.com/rpg-tools/toolbox-services/lib"
+
+// GameSystem describes the character sheets of a game.
+type GameSystem struct {
+	Id          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Sheet       *lib.JsonSchema `json:"sheet"`
+	// Formulas of the fields derived from the sheet, by name, see Formula.
+	Derived map[string]string `json:"derived"`
+
+	formulas map[string]*Formula
+	// Derived fields in dependency order.
+	order []string
+}
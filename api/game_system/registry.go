@@ -0,0 +1,88 @@
+package game_system
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"sort"
+	"sync"
+)
+
+var (
+	mutex   sync.RWMutex
+	systems = map[string]*GameSystem{}
+)
+
+// Register adds a game system, or replaces the one with the same id. Its derived fields must be valid formulas
+// without cycles.
+func Register(system *GameSystem) error {
+	if err := system.compile(); err != nil {
+		return fmt.Errorf("game system %s: %v", system.Id, err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	systems[system.Id] = system
+	return nil
+}
+
+// mustRegister registers a built-in game system.
+func mustRegister(system *GameSystem) {
+	if err := Register(system); err != nil {
+		panic(err)
+	}
+}
+
+// ById returns the game system, nil when it is unknown.
+func ById(id string) *GameSystem {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return systems[id]
+}
+
+// All returns the game systems sorted by id.
+func All() []*GameSystem {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	res := make([]*GameSystem, 0, len(systems))
+	for _, s := range systems {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Id < res[j].Id })
+	return res
+}
+
+// ValidateSheet validates the sheet of a character of the game system and computes its derived fields,
+// the defaults of the system are used when the sheet is nil. Any object is a valid sheet, without derived fields,
+// when the table has no game system.
+func ValidateSheet(systemId string, sheet map[string]interface{}) (map[string]interface{}, map[string]float64, error) {
+	if systemId == "" {
+		if sheet == nil {
+			sheet = map[string]interface{}{}
+		}
+		return sheet, map[string]float64{}, nil
+	}
+	system := ById(systemId)
+	if system == nil {
+		return nil, nil, lib.HttpBadRequest(fmt.Errorf("unknown game system %s", systemId))
+	}
+	if sheet == nil {
+		sheet, _ = system.Sheet.Defaults().(map[string]interface{})
+	}
+	if errs := system.Sheet.Validate(sheet); len(errs) > 0 {
+		return nil, nil, lib.HttpValidationError(errs)
+	}
+	derived, errs := system.Derive(sheet)
+	if len(errs) > 0 {
+		return nil, nil, lib.HttpValidationError(errs)
+	}
+	return sheet, derived, nil
+}
+
+// mustSchema reads the schema of a built-in game system.
+func mustSchema(data string) *lib.JsonSchema {
+	res := &lib.JsonSchema{}
+	if err := json.Unmarshal([]byte(data), res); err != nil {
+		panic(err)
+	}
+	return res
+}
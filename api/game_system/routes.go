@@ -0,0 +1,32 @@
+package game_system
+
+import (
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"net/http"
+)
+
+func findAllRoute(w http.ResponseWriter, r *http.Request) {
+	if err := render.Render(w, r, lib.HttpResponse(All(), 200)); err != nil {
+		_ = render.Render(w, r, lib.HttpRenderError(err))
+		return
+	}
+}
+
+func findOneRoute(w http.ResponseWriter, r *http.Request) {
+	res := ById(chi.URLParam(r, "id"))
+	if res == nil {
+		_ = render.Render(w, r, lib.HttpNotFound(nil))
+		return
+	}
+	if err := render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+		_ = render.Render(w, r, lib.HttpRenderError(err))
+		return
+	}
+}
+
+func Route(router chi.Router) {
+	router.Get("/", findAllRoute)
+	router.Get("/{id}", findOneRoute)
+}
@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeysCollectionName = "api_keys"
+	botSubjectPrefix      = "bot|"
+)
+
+func hashApiKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// ownedBot returns the bot if it is owned by the authenticated user.
+func (s *userServices) ownedBot(id string, ctx context.Context) (*User, error) {
+	bot, err := s.ById(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if bot == nil || !bot.Bot || bot.Owner != app_context.GetAuthUser(ctx) {
+		return nil, lib.HttpNotFound(fmt.Errorf("bot %s not found", id))
+	}
+	return bot, nil
+}
+
+// Read part
+
+func (*userServices) MyBots(ctx context.Context) ([]*User, error) {
+	db := app_context.GetMongodb(ctx)
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"bot": true, "owner": app_context.GetAuthUser(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*User, 0)
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *userServices) ApiKeys(botId string, ctx context.Context) ([]*ApiKey, error) {
+	db := app_context.GetMongodb(ctx)
+	if _, err := s.ownedBot(botId, ctx); err != nil {
+		return nil, err
+	}
+	cursor, err := db.Collection(apiKeysCollectionName).Find(ctx, bson.M{"bot": botId})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ApiKey, 0)
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Commands.
+
+func (*userServices) CreateBot(cmd CreateBotCmd, ctx context.Context) (*User, error) {
+	db := app_context.GetMongodb(ctx)
+	if app_context.IsAuthBot(ctx) {
+		return nil, lib.HttpForbidden(fmt.Errorf("a bot cannot own bots"))
+	}
+	if cmd.Name == "" {
+		return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+	}
+	id := primitive.NewObjectID()
+	bot := &User{
+		Id:        id,
+		Subject:   botSubjectPrefix + id.Hex(),
+		Name:      cmd.Name,
+		Picture:   cmd.Picture,
+		CreatedAt: time.Now(),
+		Bot:       true,
+		Owner:     app_context.GetAuthUser(ctx),
+	}
+	if _, err := db.Collection(collectionName).InsertOne(ctx, bot); err != nil {
+		return nil, err
+	}
+	return bot, nil
+}
+
+// CreateApiKey generates a key for the bot, only its hash is stored.
+func (s *userServices) CreateApiKey(botId string, cmd CreateApiKeyCmd, ctx context.Context) (*ApiKeyWithSecret, error) {
+	db := app_context.GetMongodb(ctx)
+	if _, err := s.ownedBot(botId, ctx); err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	id := primitive.NewObjectID()
+	key := lib.ApiKeyPrefix + id.Hex() + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	res := &ApiKeyWithSecret{
+		ApiKey: ApiKey{Id: id, Bot: botId, Name: cmd.Name, Hash: hashApiKey(key), CreatedAt: time.Now()},
+		Key:    key,
+	}
+	if _, err := db.Collection(apiKeysCollectionName).InsertOne(ctx, &res.ApiKey); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *userServices) RevokeApiKey(botId string, keyId string, ctx context.Context) (*ApiKey, error) {
+	db := app_context.GetMongodb(ctx)
+	if _, err := s.ownedBot(botId, ctx); err != nil {
+		return nil, err
+	}
+	bsonId, err := primitive.ObjectIDFromHex(keyId)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	now := time.Now()
+	filter := bson.M{"_id": bsonId, "bot": botId}
+	if _, err := db.Collection(apiKeysCollectionName).UpdateOne(ctx, bson.M{"_id": bsonId, "bot": botId, "revokedAt": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"revokedAt": now}}); err != nil {
+		return nil, err
+	}
+	res := &ApiKey{}
+	err = db.Collection(apiKeysCollectionName).FindOne(ctx, filter).Decode(res)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("api key %s not found", keyId))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ValidateApiKey returns the subject of the bot owning the key, it is used by the auth middlewares.
+func ValidateApiKey(key string, ctx context.Context) (string, error) {
+	db := app_context.GetMongodb(ctx)
+	parts := strings.SplitN(strings.TrimPrefix(key, lib.ApiKeyPrefix), "_", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed api key")
+	}
+	id, err := primitive.ObjectIDFromHex(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("malformed api key")
+	}
+	apiKey := &ApiKey{}
+	err = db.Collection(apiKeysCollectionName).FindOne(ctx, bson.M{"_id": id, "revokedAt": bson.M{"$exists": false}}).Decode(apiKey)
+	if err == mongo.ErrNoDocuments {
+		return "", fmt.Errorf("unknown api key")
+	}
+	if err != nil {
+		return "", err
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(hashApiKey(key))) != 1 {
+		return "", fmt.Errorf("unknown api key")
+	}
+	_, _ = db.Collection(apiKeysCollectionName).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastUsedAt": time.Now()}})
+	return botSubjectPrefix + apiKey.Bot, nil
+}
@@ -0,0 +1,15 @@
+package user
+
+type UpdateUserCmd struct {
+	Name    *string `json:"name"`
+	Picture *string `json:"picture"`
+}
+
+type CreateBotCmd struct {
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+}
+
+type CreateApiKeyCmd struct {
+	Name string `json:"name"`
+}
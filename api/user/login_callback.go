@@ -1,5 +1,158 @@
 package user
 
-import "github.com/dgrijalva/jwt-go"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/go-chi/render"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"google.golang.org/grpc"
+	"net/http"
+	"sync"
+	"time"
+)
 
-func OnLogin(jwt jwt.Token) {}
+type authUser struct {
+	id  string
+	bot bool
+}
+
+const (
+	// Maximum count of tokens remembered, the expired ones are dropped first.
+	maxSeenTokens = 10000
+	// Tokens without expiration, like the api keys, are remembered for this delay at most.
+	maxSeenTokenTtl = time.Hour
+)
+
+type seenToken struct {
+	user      *authUser
+	expiresAt time.Time
+}
+
+// tokenCache keeps the users by raw token until the tokens expire, to provision the user only on the first
+// request of a login.
+type tokenCache struct {
+	mutex  sync.Mutex
+	tokens map[string]seenToken
+}
+
+var seenTokens = &tokenCache{tokens: make(map[string]seenToken)}
+
+func (c *tokenCache) load(raw string) (*authUser, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t, ok := c.tokens[raw]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(t.expiresAt) {
+		delete(c.tokens, raw)
+		return nil, false
+	}
+	return t.user, true
+}
+
+func (c *tokenCache) store(raw string, u *authUser, expiresAt time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.tokens) >= maxSeenTokens {
+		now := time.Now()
+		for k, t := range c.tokens {
+			if now.After(t.expiresAt) {
+				delete(c.tokens, k)
+			}
+		}
+	}
+	if len(c.tokens) >= maxSeenTokens {
+		// Drop the token expiring first, its user is provisioned again on its next request.
+		var first string
+		for k, t := range c.tokens {
+			if first == "" || t.expiresAt.Before(c.tokens[first].expiresAt) {
+				first = k
+			}
+		}
+		delete(c.tokens, first)
+	}
+	c.tokens[raw] = seenToken{user: u, expiresAt: expiresAt}
+}
+
+// tokenExpiration returns the `exp` claim of the token, bounded by the maximum delay to remember it.
+func tokenExpiration(token *jwt.Token) time.Time {
+	res := time.Now().Add(maxSeenTokenTtl)
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return res
+	}
+	var exp int64
+	switch v := claims["exp"].(type) {
+	case float64:
+		exp = int64(v)
+	case json.Number:
+		exp, _ = v.Int64()
+	}
+	if exp > 0 && time.Unix(exp, 0).Before(res) {
+		return time.Unix(exp, 0)
+	}
+	return res
+}
+
+// OnLogin returns the user of the token, provisioned on the first request of the token.
+func OnLogin(token *jwt.Token, ctx context.Context) (*User, error) {
+	return (&userServices{}).OnLogin(token, ctx)
+}
+
+func authUserOf(token *jwt.Token, ctx context.Context) (*authUser, error) {
+	if u, ok := seenTokens.load(token.Raw); ok {
+		return u, nil
+	}
+	u, err := OnLogin(token, ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &authUser{id: u.Id.Hex(), bot: u.Bot}
+	seenTokens.store(token.Raw, res, tokenExpiration(token))
+	return res, nil
+}
+
+func onLoginFromContext(ctx context.Context) (context.Context, error) {
+	token, ok := ctx.Value(app_context.AuthTokenContextKey).(*jwt.Token)
+	if !ok {
+		return nil, lib.HttpUnauthorized(fmt.Errorf("token not found"))
+	}
+	u, err := authUserOf(token, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app_context.WithAuthUser(u.id, u.bot)(ctx), nil
+}
+
+// LoginMiddleware provisions the user and sets its id in the context,
+// it must be used after the auth and the context middlewares.
+func LoginMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := onLoginFromContext(r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func LoginUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := onLoginFromContext(ctx)
+	if err != nil {
+		return nil, lib.ToGrpcError(err)
+	}
+	return handler(ctx, req)
+}
+
+func LoginStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := onLoginFromContext(stream.Context())
+	if err != nil {
+		return lib.ToGrpcError(err)
+	}
+	return handler(srv, lib.WithStreamContext(stream, ctx))
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type User struct {
+	Id primitive.ObjectID `json:"id" bson:"_id"`
+	// `sub` claim of the tokens, the identity of the user.
+	Subject     string    `json:"-" bson:"subject,omitempty"`
+	Email       string    `json:"email" bson:"email"`
+	Name        string    `json:"name" bson:"name"`
+	Picture     string    `json:"picture" bson:"picture"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	LastLoginAt time.Time `json:"lastLoginAt" bson:"lastLoginAt"`
+	// Bots act with api keys, on behalf of their owner.
+	Bot   bool   `json:"bot" bson:"bot"`
+	Owner string `json:"owner,omitempty" bson:"owner,omitempty"`
+}
+
+// Profile is the public part of a user, visible by the other users.
+type Profile struct {
+	Id      primitive.ObjectID `json:"id" bson:"_id"`
+	Name    string             `json:"name" bson:"name"`
+	Picture string             `json:"picture" bson:"picture"`
+	Bot     bool               `json:"bot" bson:"bot"`
+}
+
+func (u *User) Profile() Profile {
+	return Profile{Id: u.Id, Name: u.Name, Picture: u.Picture, Bot: u.Bot}
+}
+
+type ApiKey struct {
+	Id         primitive.ObjectID `json:"id" bson:"_id"`
+	Bot        string             `json:"bot" bson:"bot"`
+	Name       string             `json:"name" bson:"name"`
+	Hash       string             `json:"-" bson:"hash"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	LastUsedAt *time.Time         `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
+
+// ApiKeyWithSecret is returned only once, at the creation of the key.
+type ApiKeyWithSecret struct {
+	ApiKey `bson:",inline"`
+	Key    string `json:"key" bson:"-"`
+}
@@ -1 +1,164 @@
 package user
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"net/http"
+)
+
+func meRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.Me(r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if res == nil {
+			_ = render.Render(w, r, lib.HttpNotFound(nil))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func updateMeRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := json.NewDecoder(r.Body)
+		payload := UpdateUserCmd{}
+		if err := d.Decode(&payload); err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.UpdateMe(payload, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if res == nil {
+			_ = render.Render(w, r, lib.HttpNotFound(nil))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func findOneUserRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.ById(chi.URLParam(r, "id"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if res == nil {
+			_ = render.Render(w, r, lib.HttpNotFound(nil))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res.Profile(), 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func myBotsRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.MyBots(r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func createBotRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := json.NewDecoder(r.Body)
+		payload := CreateBotCmd{}
+		if err := d.Decode(&payload); err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.CreateBot(payload, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.Id.Hex(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func apiKeysRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.ApiKeys(chi.URLParam(r, "bot"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func createApiKeyRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := json.NewDecoder(r.Body)
+		payload := CreateApiKeyCmd{}
+		if err := d.Decode(&payload); err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.CreateApiKey(chi.URLParam(r, "bot"), payload, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.Id.Hex(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func revokeApiKeyRoute(services *userServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.RevokeApiKey(chi.URLParam(r, "bot"), chi.URLParam(r, "key"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func Route(router chi.Router) {
+	services := &userServices{}
+
+	router.Get("/me", meRoute(services))
+	router.Patch("/me", updateMeRoute(services))
+	router.Get("/me/bots", myBotsRoute(services))
+	router.Post("/me/bots", createBotRoute(services))
+	router.Get("/me/bots/{bot}/keys", apiKeysRoute(services))
+	router.Post("/me/bots/{bot}/keys", createApiKeyRoute(services))
+	router.Delete("/me/bots/{bot}/keys/{key}", revokeApiKeyRoute(services))
+	router.Get("/{id}", findOneUserRoute(services))
+}
@@ -1,3 +1,194 @@
 package user
 
+import (
+	"context"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	collectionName = "users"
+)
+
 type userServices struct{}
+
+func claimString(claims jwt.MapClaims, key string) string {
+	v, _ := claims[key].(string)
+	return v
+}
+
+// claimBool reads a boolean claim, some providers send it as a string.
+func claimBool(claims jwt.MapClaims, key string) bool {
+	switch v := claims[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+func (*userServices) findOne(filter bson.M, ctx context.Context) (*User, error) {
+	db := app_context.GetMongodb(ctx)
+	res := &User{}
+	err := db.Collection(collectionName).FindOne(ctx, filter).Decode(res)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Read part
+
+func (s *userServices) Me(ctx context.Context) (*User, error) {
+	return s.ById(app_context.GetAuthUser(ctx), ctx)
+}
+
+func (s *userServices) ById(id string, ctx context.Context) (*User, error) {
+	bsonId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	return s.findOne(bson.M{"_id": bsonId}, ctx)
+}
+
+func (*userServices) ByIds(ids []string, ctx context.Context) (map[string]*User, error) {
+	db := app_context.GetMongodb(ctx)
+	res := make(map[string]*User)
+	bsonIds := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		// Ignore the wildcards and the unknown users.
+		if bsonId, err := primitive.ObjectIDFromHex(id); err == nil {
+			bsonIds = append(bsonIds, bsonId)
+		}
+	}
+	if len(bsonIds) == 0 {
+		return res, nil
+	}
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"_id": bson.M{"$in": bsonIds}})
+	if err != nil {
+		return nil, err
+	}
+	all := make([]*User, 0)
+	if err = cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	for _, u := range all {
+		res[u.Id.Hex()] = u
+	}
+	return res, nil
+}
+
+// Commands.
+
+// OnLogin creates the user of the token the first time its subject is seen, the name and the picture are then
+// owned by the user. The users known only by their email, before the identities were subjects, are linked to it
+// when the email is verified.
+func (*userServices) OnLogin(token *jwt.Token, ctx context.Context) (*User, error) {
+	db := app_context.GetMongodb(ctx)
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, lib.HttpUnauthorized(fmt.Errorf("cannot read claims of token"))
+	}
+	subject := claimString(claims, "sub")
+	if subject == "" {
+		return nil, lib.HttpUnauthorized(fmt.Errorf("sub claim is missing"))
+	}
+	email := claimString(claims, "email")
+	now := time.Now()
+	set := bson.M{"lastLoginAt": now}
+	if email != "" {
+		set["email"] = email
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	res := &User{}
+	err := db.Collection(collectionName).FindOneAndUpdate(ctx, bson.M{"subject": subject}, bson.M{"$set": set}, opts).Decode(res)
+	if err == nil {
+		return res, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	set["subject"] = subject
+	update := bson.M{
+		"$set": set,
+		"$setOnInsert": bson.M{
+			"_id":       primitive.NewObjectID(),
+			"name":      claimString(claims, "name"),
+			"picture":   claimString(claims, "picture"),
+			"createdAt": now,
+		},
+	}
+	// Only a verified email links the legacy user, anyone may sign up with the email of another.
+	// Otherwise the filter matches nothing and the user is created.
+	filter := bson.M{"email": email, "subject": bson.M{"$exists": false}}
+	if email == "" || !claimBool(claims, "email_verified") {
+		filter = bson.M{"subject": subject}
+	}
+	if err := db.Collection(collectionName).FindOneAndUpdate(ctx, filter, update, opts.SetUpsert(true)).Decode(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *userServices) UpdateMe(cmd UpdateUserCmd, ctx context.Context) (*User, error) {
+	db := app_context.GetMongodb(ctx)
+	set := bson.M{}
+	if cmd.Name != nil {
+		set["name"] = *cmd.Name
+	}
+	if cmd.Picture != nil {
+		set["picture"] = *cmd.Picture
+	}
+	if len(set) == 0 {
+		return s.Me(ctx)
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	res := &User{}
+	id, err := primitive.ObjectIDFromHex(app_context.GetAuthUser(ctx))
+	if err != nil {
+		return nil, err
+	}
+	err = db.Collection(collectionName).FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, opts).Decode(res)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsBotOwnedBy tells whether the user is a bot owned by the owner.
+func IsBotOwnedBy(id string, owner string, ctx context.Context) (bool, error) {
+	bot, err := (&userServices{}).ById(id, ctx)
+	if err != nil {
+		return false, err
+	}
+	return bot != nil && bot.Bot && bot.Owner == owner, nil
+}
+
+// Profiles resolves the public profiles of the users by id, the unknown ones are missing from the result.
+func Profiles(ids []string, ctx context.Context) (map[string]Profile, error) {
+	users, err := (&userServices{}).ByIds(ids, ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]Profile, len(users))
+	for id, u := range users {
+		res[id] = u.Profile()
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/user"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *tableServices) InviteBot(table *Table, cmd *InviteBotCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	// Only the own bots of the user are invited.
+	owned, err := user.IsBotOwnedBy(cmd.Bot, by, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, lib.HttpNotFound(fmt.Errorf("bot %s not found", cmd.Bot))
+	}
+	// A bot is never allowed more than the user who invites it.
+	role := table.RoleOf(by)
+	for _, kind := range cmd.Commands {
+		if _, ok := commandsSupplierByKind[kind]; !ok {
+			return nil, lib.HttpBadRequest(fmt.Errorf("%s is not a valid command", kind))
+		}
+		if kind == InviteBotKind || kind == RemoveBotKind || ownerOnlyKinds[kind] {
+			return nil, lib.HttpBadRequest(fmt.Errorf("bots cannot be allowed to run %s", kind))
+		}
+		if !table.Settings.Allows(role, kind) {
+			return nil, &PermissionDeniedError{Table: table.Id.Hex(), User: by, Role: role, Command: kind}
+		}
+	}
+	commands := cmd.Commands
+	if commands == nil {
+		commands = []CommandKind{}
+	}
+
+	// Invite again a bot replaces its allowed commands.
+	bots := []TableBot{{Id: cmd.Bot, Commands: commands}}
+	for _, b := range table.Bots {
+		if b.Id != cmd.Bot {
+			bots = append(bots, b)
+		}
+	}
+	evt := &BotInvited{EventBase: NewEventBase(table.Id, []string{"*"}, by), Bot: cmd.Bot, Commands: commands}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"bots": bots}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) RemoveBot(table *Table, cmd *RemoveBotCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if table.Bot(cmd.Bot) == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("bot %s not found", cmd.Bot))
+	}
+	evt := &BotRemoved{EventBase: NewEventBase(table.Id, []string{"*"}, by), Bot: cmd.Bot}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$pull": bson.M{"bots": bson.M{"id": cmd.Bot}}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,158 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rpg-tools/toolbox-services/api/game_system"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// editableCharacter returns the character if the authenticated user can edit it: the masters edit any character,
+// the players only their own ones.
+func editableCharacter(table *Table, id string, ctx context.Context) (*Character, error) {
+	user := app_context.GetAuthUser(ctx)
+	character := table.Character(id)
+	if character == nil || !table.Includes(character.Audience(), user) {
+		return nil, lib.HttpNotFound(fmt.Errorf("character %s not found", id))
+	}
+	if !table.IsMaster(user) && character.Player != user {
+		return nil, lib.HttpForbidden(fmt.Errorf("character %s is not yours", id))
+	}
+	return character, nil
+}
+
+// visibleCharacters removes the characters the user cannot see.
+func (t *Table) visibleCharacters(user string) {
+	characters := make([]Character, 0, len(t.Characters))
+	for _, c := range t.Characters {
+		if t.Includes(c.Audience(), user) {
+			characters = append(characters, c)
+		}
+	}
+	t.Characters = characters
+}
+
+// Commands.
+
+func (s *tableServices) CreateCharacter(table *Table, cmd *CreateCharacterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if cmd.Name == "" {
+		return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+	}
+	sheet, derived, err := game_system.ValidateSheet(table.Settings.GameSystem, cmd.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	character := Character{Id: uuid.New().String(), Table: table.Id.Hex(), Player: cmd.Player, Name: cmd.Name, Picture: cmd.Picture, Hidden: cmd.Hidden, Sheet: sheet, Derived: derived, Macros: []Macro{}}
+	if table.IsMaster(by) {
+		if character.Player != "" && table.RoleOf(character.Player) != PlayerRole {
+			return nil, lib.HttpBadRequest(fmt.Errorf("%s is not a player of the table", character.Player))
+		}
+	} else {
+		if character.Player == "" {
+			character.Player = by
+		}
+		if character.Player != by || character.Hidden {
+			return nil, lib.HttpForbidden(fmt.Errorf("players create only their own visible characters"))
+		}
+	}
+
+	evt := &CharacterCreated{EventBase: NewEventBase(table.Id, character.Audience(), by), Character: character}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$push": bson.M{"characters": character}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// UpdateCharacter updates the name, the picture, the sheet, and for the masters the visibility of a character.
+func (s *tableServices) UpdateCharacter(table *Table, cmd *UpdateCharacterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character, err := editableCharacter(table, cmd.Character, ctx)
+	if err != nil {
+		return nil, err
+	}
+	updated := *character
+	if cmd.Name != nil {
+		if *cmd.Name == "" {
+			return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+		}
+		updated.Name = *cmd.Name
+	}
+	if cmd.Picture != nil {
+		updated.Picture = *cmd.Picture
+	}
+	if cmd.Sheet != nil {
+		if updated.Sheet, updated.Derived, err = game_system.ValidateSheet(table.Settings.GameSystem, cmd.Sheet); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Hidden != nil {
+		if !table.IsMaster(by) {
+			return nil, lib.HttpForbidden(fmt.Errorf("only the masters hide characters"))
+		}
+		updated.Hidden = *cmd.Hidden
+	}
+
+	// Sent to the users who see the character after the update.
+	evt := &CharacterUpdated{EventBase: NewEventBase(table.Id, updated.Audience(), by), Character: updated}
+	update := bson.M{"$set": bson.M{"characters.$[c]": updated}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"c.id": character.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) AssignCharacter(table *Table, cmd *AssignCharacterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character := table.Character(cmd.Character)
+	if character == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("character %s not found", cmd.Character))
+	}
+	if cmd.Player != "" && table.RoleOf(cmd.Player) != PlayerRole {
+		return nil, lib.HttpBadRequest(fmt.Errorf("%s is not a player of the table", cmd.Player))
+	}
+	updated := *character
+	updated.Player = cmd.Player
+
+	evt := &CharacterAssigned{EventBase: NewEventBase(table.Id, updated.Audience(), by), Character: character.Id, Player: cmd.Player}
+	update := bson.M{"$set": bson.M{"characters.$[c].player": cmd.Player}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"c.id": character.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) RetireCharacter(table *Table, cmd *RetireCharacterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character, err := editableCharacter(table, cmd.Character, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if character.Retired {
+		return nil, lib.HttpConflict(fmt.Errorf("character %s is already retired", cmd.Character))
+	}
+
+	evt := &CharacterRetired{EventBase: NewEventBase(table.Id, character.Audience(), by), Character: character.Id}
+	update := bson.M{"$set": bson.M{"characters.$[c].retired": true}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"c.id": character.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) DeleteCharacter(table *Table, cmd *DeleteCharacterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character := table.Character(cmd.Character)
+	if character == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("character %s not found", cmd.Character))
+	}
+
+	evt := &CharacterDeleted{EventBase: NewEventBase(table.Id, character.Audience(), by), Character: character.Id}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$pull": bson.M{"characters": bson.M{"id": character.Id}}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
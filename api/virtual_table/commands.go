@@ -1,5 +1,537 @@
 package virtual_table
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type CommandKind string
+
+const (
+	CreateTableKind CommandKind = "cmd:create-table"
+	SendMessageKind CommandKind = "cmd:send-message"
+	InviteBotKind   CommandKind = "cmd:invite-bot"
+	RemoveBotKind   CommandKind = "cmd:remove-bot"
+	// Settings
+	UpdateSettingsKind CommandKind = "cmd:update-settings"
+	// Invites
+	CreateInviteKind CommandKind = "cmd:create-invite"
+	RevokeInviteKind CommandKind = "cmd:revoke-invite"
+	JoinTableKind    CommandKind = "cmd:join-table"
+	// Lobby
+	UpdateLobbyKind CommandKind = "cmd:update-lobby"
+	// Join requests
+	RequestJoinKind        CommandKind = "cmd:request-join"
+	ApproveJoinRequestKind CommandKind = "cmd:approve-join-request"
+	RejectJoinRequestKind  CommandKind = "cmd:reject-join-request"
+	// Characters
+	CreateCharacterKind CommandKind = "cmd:create-character"
+	UpdateCharacterKind CommandKind = "cmd:update-character"
+	AssignCharacterKind CommandKind = "cmd:assign-character"
+	RetireCharacterKind CommandKind = "cmd:retire-character"
+	DeleteCharacterKind CommandKind = "cmd:delete-character"
+	SaveMacroKind       CommandKind = "cmd:save-macro"
+	DeleteMacroKind     CommandKind = "cmd:delete-macro"
+	RunMacroKind        CommandKind = "cmd:run-macro"
+	// Templates
+	CloneTableKind       CommandKind = "cmd:clone-table"
+	UpdateDiscussionKind CommandKind = "cmd:update-discussion"
+
+	CreateHandoutKind CommandKind = "cmd:create-handout"
+	ShareHandoutKind  CommandKind = "cmd:share-handout"
+	DeleteHandoutKind CommandKind = "cmd:delete-handout"
+	// Lifecycle
+	ArchiveTableKind CommandKind = "cmd:archive-table"
+	DeleteTableKind  CommandKind = "cmd:delete-table"
+	RestoreTableKind CommandKind = "cmd:restore-table"
+	// Masters
+	TransferOwnershipKind CommandKind = "cmd:transfer-ownership"
+	AddCoMasterKind       CommandKind = "cmd:add-co-master"
+	RemoveCoMasterKind    CommandKind = "cmd:remove-co-master"
+	// Moderation
+	KickPlayerKind  CommandKind = "cmd:kick-player"
+	BanPlayerKind   CommandKind = "cmd:ban-player"
+	UnbanPlayerKind CommandKind = "cmd:unban-player"
+	MutePlayerKind  CommandKind = "cmd:mute-player"
+
+	RollDiceKind   CommandKind = "cmd:roll-dice"
+	RevealRollKind CommandKind = "cmd:reveal-roll"
+
+	StartDiceSessionKind CommandKind = "cmd:start-dice-session"
+	AddClientSeedKind    CommandKind = "cmd:add-client-seed"
+	EndDiceSessionKind   CommandKind = "cmd:end-dice-session"
+
+	StartEncounterKind  CommandKind = "cmd:start-encounter"
+	AddCombatantKind    CommandKind = "cmd:add-combatant"
+	RemoveCombatantKind CommandKind = "cmd:remove-combatant"
+	NextTurnKind        CommandKind = "cmd:next-turn"
+	PreviousTurnKind    CommandKind = "cmd:previous-turn"
+	DelayTurnKind       CommandKind = "cmd:delay-turn"
+	ReadyActionKind     CommandKind = "cmd:ready-action"
+	ActNowKind          CommandKind = "cmd:act-now"
+	EndEncounterKind    CommandKind = "cmd:end-encounter"
+)
+
+// Commands which can be sent on the socket of a table.
+var commandsSupplierByKind = map[CommandKind]func() Command{
+	SendMessageKind:        func() Command { return &SendMessageCmd{} },
+	InviteBotKind:          func() Command { return &InviteBotCmd{} },
+	RemoveBotKind:          func() Command { return &RemoveBotCmd{} },
+	UpdateSettingsKind:     func() Command { return &UpdateSettingsCmd{} },
+	CreateInviteKind:       func() Command { return &CreateInviteCmd{} },
+	RevokeInviteKind:       func() Command { return &RevokeInviteCmd{} },
+	UpdateLobbyKind:        func() Command { return &UpdateLobbyCmd{} },
+	ApproveJoinRequestKind: func() Command { return &ApproveJoinRequestCmd{} },
+	RejectJoinRequestKind:  func() Command { return &RejectJoinRequestCmd{} },
+	CreateCharacterKind:    func() Command { return &CreateCharacterCmd{} },
+	UpdateCharacterKind:    func() Command { return &UpdateCharacterCmd{} },
+	AssignCharacterKind:    func() Command { return &AssignCharacterCmd{} },
+	RetireCharacterKind:    func() Command { return &RetireCharacterCmd{} },
+	DeleteCharacterKind:    func() Command { return &DeleteCharacterCmd{} },
+	SaveMacroKind:          func() Command { return &SaveMacroCmd{} },
+	DeleteMacroKind:        func() Command { return &DeleteMacroCmd{} },
+	RunMacroKind:           func() Command { return &RunMacroCmd{} },
+	UpdateDiscussionKind:   func() Command { return &UpdateDiscussionCmd{} },
+	CreateHandoutKind:      func() Command { return &CreateHandoutCmd{} },
+	ShareHandoutKind:       func() Command { return &ShareHandoutCmd{} },
+	DeleteHandoutKind:      func() Command { return &DeleteHandoutCmd{} },
+	ArchiveTableKind:       func() Command { return &ArchiveTableCmd{} },
+	DeleteTableKind:        func() Command { return &DeleteTableCmd{} },
+	TransferOwnershipKind:  func() Command { return &TransferOwnershipCmd{} },
+	AddCoMasterKind:        func() Command { return &AddCoMasterCmd{} },
+	RemoveCoMasterKind:     func() Command { return &RemoveCoMasterCmd{} },
+	KickPlayerKind:         func() Command { return &KickPlayerCmd{} },
+	BanPlayerKind:          func() Command { return &BanPlayerCmd{} },
+	UnbanPlayerKind:        func() Command { return &UnbanPlayerCmd{} },
+	MutePlayerKind:         func() Command { return &MutePlayerCmd{} },
+	RollDiceKind:           func() Command { return &RollDiceCmd{} },
+	RevealRollKind:         func() Command { return &RevealRollCmd{} },
+	StartDiceSessionKind:   func() Command { return &StartDiceSessionCmd{} },
+	AddClientSeedKind:      func() Command { return &AddClientSeedCmd{} },
+	EndDiceSessionKind:     func() Command { return &EndDiceSessionCmd{} },
+	StartEncounterKind:     func() Command { return &StartEncounterCmd{} },
+	AddCombatantKind:       func() Command { return &AddCombatantCmd{} },
+	RemoveCombatantKind:    func() Command { return &RemoveCombatantCmd{} },
+	NextTurnKind:           func() Command { return &NextTurnCmd{} },
+	PreviousTurnKind:       func() Command { return &PreviousTurnCmd{} },
+	DelayTurnKind:          func() Command { return &DelayTurnCmd{} },
+	ReadyActionKind:        func() Command { return &ReadyActionCmd{} },
+	ActNowKind:             func() Command { return &ActNowCmd{} },
+	EndEncounterKind:       func() Command { return &EndEncounterCmd{} },
+}
+
+// ReadCommandJson reads a command from its json, its kind is given by the `_kind` field.
+func ReadCommandJson(data []byte) (Command, error) {
+	value := struct {
+		Kind CommandKind `json:"_kind"`
+	}{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	if value.Kind == "" {
+		return nil, fmt.Errorf("'_kind' not found in data")
+	}
+	sup, ok := commandsSupplierByKind[value.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid command", value.Kind)
+	}
+	cmd := sup()
+	if err := json.Unmarshal(data, cmd); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+type Command interface {
+	Kind() CommandKind
+}
+
 type CreateTableCmd struct {
 	Name string `json:"name"`
+	// Id of the game system of the table, optional.
+	GameSystem string `json:"gameSystem"`
+}
+
+func (*CreateTableCmd) Kind() CommandKind { return CreateTableKind }
+
+type SendMessageCmd struct {
+	Discussion string `json:"discussion"`
+	Message    string `json:"message"`
+}
+
+func (*SendMessageCmd) Kind() CommandKind { return SendMessageKind }
+
+type InviteBotCmd struct {
+	Bot string `json:"bot"`
+	// Commands the bot is allowed to run at the table.
+	Commands []CommandKind `json:"commands"`
+}
+
+func (*InviteBotCmd) Kind() CommandKind { return InviteBotKind }
+
+type RemoveBotCmd struct {
+	Bot string `json:"bot"`
+}
+
+func (*RemoveBotCmd) Kind() CommandKind { return RemoveBotKind }
+
+type UpdateSettingsCmd struct {
+	// Overrides of the roles allowed to run a command, null removes the override and an empty list
+	// allows the command only to the owner.
+	Permissions  map[CommandKind][]Role `json:"permissions"`
+	Discoverable *bool                  `json:"discoverable"`
+	Template     *bool                  `json:"template"`
+	// The sheets of the existing characters are validated on their next update.
+	GameSystem *string `json:"gameSystem"`
+}
+
+func (*UpdateSettingsCmd) Kind() CommandKind { return UpdateSettingsKind }
+
+type CreateInviteCmd struct {
+	// Role given to the users joining with the invite, player by default.
+	Role Role `json:"role"`
+	// Expiration of the invite, in 7 days by default.
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Maximum number of uses, 1 for a single-use invite and 0 for unlimited uses.
+	MaxUses int `json:"maxUses"`
+}
+
+func (*CreateInviteCmd) Kind() CommandKind { return CreateInviteKind }
+
+type RevokeInviteCmd struct {
+	Invite string `json:"invite"`
+}
+
+func (*RevokeInviteCmd) Kind() CommandKind { return RevokeInviteKind }
+
+type RequestJoinCmd struct {
+	Message string `json:"message"`
+}
+
+func (*RequestJoinCmd) Kind() CommandKind { return RequestJoinKind }
+
+type ApproveJoinRequestCmd struct {
+	Request string `json:"request"`
+}
+
+func (*ApproveJoinRequestCmd) Kind() CommandKind { return ApproveJoinRequestKind }
+
+type RejectJoinRequestCmd struct {
+	Request string `json:"request"`
+	Reason  string `json:"reason"`
 }
+
+func (*RejectJoinRequestCmd) Kind() CommandKind { return RejectJoinRequestKind }
+
+type UpdateLobbyCmd struct {
+	Lobby
+}
+
+func (*UpdateLobbyCmd) Kind() CommandKind { return UpdateLobbyKind }
+
+type CreateCharacterCmd struct {
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+	// Player of the character, the authenticated user by default. Masters create NPCs without player.
+	Player string `json:"player"`
+	Hidden bool   `json:"hidden"`
+	// Sheet of the character, the defaults of the game system when missing.
+	Sheet map[string]interface{} `json:"sheet"`
+}
+
+func (*CreateCharacterCmd) Kind() CommandKind { return CreateCharacterKind }
+
+// UpdateCharacterCmd updates the given fields of a character.
+type UpdateCharacterCmd struct {
+	Character string  `json:"character"`
+	Name      *string `json:"name"`
+	Picture   *string `json:"picture"`
+	Hidden    *bool   `json:"hidden"`
+	// Replaces the whole sheet.
+	Sheet map[string]interface{} `json:"sheet"`
+}
+
+func (*UpdateCharacterCmd) Kind() CommandKind { return UpdateCharacterKind }
+
+type AssignCharacterCmd struct {
+	Character string `json:"character"`
+	// Player of the character, empty to make it a NPC.
+	Player string `json:"player"`
+}
+
+func (*AssignCharacterCmd) Kind() CommandKind { return AssignCharacterKind }
+
+type RetireCharacterCmd struct {
+	Character string `json:"character"`
+}
+
+func (*RetireCharacterCmd) Kind() CommandKind { return RetireCharacterKind }
+
+type DeleteCharacterCmd struct {
+	Character string `json:"character"`
+}
+
+func (*DeleteCharacterCmd) Kind() CommandKind { return DeleteCharacterKind }
+
+// SaveMacroCmd creates a macro of a character, or replaces the one with the same name.
+type SaveMacroCmd struct {
+	Character string `json:"character"`
+	Name      string `json:"name"`
+	// Dice expression with parameters, like `1d20 + @strModifier + @proficiencyBonus`.
+	Expression string `json:"expression"`
+}
+
+func (*SaveMacroCmd) Kind() CommandKind { return SaveMacroKind }
+
+type DeleteMacroCmd struct {
+	Character string `json:"character"`
+	Name      string `json:"name"`
+}
+
+func (*DeleteMacroCmd) Kind() CommandKind { return DeleteMacroKind }
+
+// RunMacroCmd rolls a macro with the current sheet of its character.
+type RunMacroCmd struct {
+	// Character of the macro, the one of the player with a macro of this name when empty.
+	Character string `json:"character"`
+	Name      string `json:"name"`
+	Label     string `json:"label"`
+	// Discussion where the roll is shown, the roll is shown to the whole table when empty.
+	Discussion string `json:"discussion"`
+	// Public by default.
+	Visibility RollVisibility `json:"visibility"`
+}
+
+func (*RunMacroCmd) Kind() CommandKind { return RunMacroKind }
+
+// CloneTableCmd creates a new table, owned by the authenticated user, from a table or a template.
+type CloneTableCmd struct {
+	// Name of the new table, the one of the cloned table by default.
+	Name string `json:"name"`
+	// Copies the characters too, without their players.
+	Characters bool `json:"characters"`
+}
+
+func (*CloneTableCmd) Kind() CommandKind { return CloneTableKind }
+
+type UpdateDiscussionCmd struct {
+	Discussion string `json:"discussion"`
+	Template   *bool  `json:"template"`
+}
+
+func (*UpdateDiscussionCmd) Kind() CommandKind { return UpdateDiscussionKind }
+
+type CreateHandoutCmd struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	// Members the handout is shared with, `*` for everyone, only the masters see it when empty.
+	SharedWith []string `json:"sharedWith"`
+}
+
+func (*CreateHandoutCmd) Kind() CommandKind { return CreateHandoutKind }
+
+// ShareHandoutCmd replaces the members a handout is shared with.
+type ShareHandoutCmd struct {
+	Handout    string   `json:"handout"`
+	SharedWith []string `json:"sharedWith"`
+}
+
+func (*ShareHandoutCmd) Kind() CommandKind { return ShareHandoutKind }
+
+type DeleteHandoutCmd struct {
+	Handout string `json:"handout"`
+}
+
+func (*DeleteHandoutCmd) Kind() CommandKind { return DeleteHandoutKind }
+
+type ArchiveTableCmd struct{}
+
+func (*ArchiveTableCmd) Kind() CommandKind { return ArchiveTableKind }
+
+type DeleteTableCmd struct{}
+
+func (*DeleteTableCmd) Kind() CommandKind { return DeleteTableKind }
+
+// RestoreTableCmd restores an archived table, or a deleted one from the trash.
+type RestoreTableCmd struct{}
+
+func (*RestoreTableCmd) Kind() CommandKind { return RestoreTableKind }
+
+type TransferOwnershipCmd struct {
+	// New owner, a member of the table. The previous owner becomes co-master.
+	User string `json:"user"`
+}
+
+func (*TransferOwnershipCmd) Kind() CommandKind { return TransferOwnershipKind }
+
+type AddCoMasterCmd struct {
+	User string `json:"user"`
+}
+
+func (*AddCoMasterCmd) Kind() CommandKind { return AddCoMasterKind }
+
+type RemoveCoMasterCmd struct {
+	User string `json:"user"`
+}
+
+func (*RemoveCoMasterCmd) Kind() CommandKind { return RemoveCoMasterKind }
+
+type KickPlayerCmd struct {
+	Player string `json:"player"`
+}
+
+func (*KickPlayerCmd) Kind() CommandKind { return KickPlayerKind }
+
+type BanPlayerCmd struct {
+	Player string `json:"player"`
+	Reason string `json:"reason"`
+}
+
+func (*BanPlayerCmd) Kind() CommandKind { return BanPlayerKind }
+
+type UnbanPlayerCmd struct {
+	Player string `json:"player"`
+}
+
+func (*UnbanPlayerCmd) Kind() CommandKind { return UnbanPlayerKind }
+
+type MutePlayerCmd struct {
+	Player     string    `json:"player"`
+	Discussion string    `json:"discussion"`
+	Until      time.Time `json:"until"`
+}
+
+func (*MutePlayerCmd) Kind() CommandKind { return MutePlayerKind }
+
+type RollDiceCmd struct {
+	// Dice expression, like `4d6kh3 + 2`.
+	Expression string `json:"expression"`
+	Label      string `json:"label"`
+	// Discussion where the roll is shown, the roll is shown to the whole table when empty.
+	Discussion string `json:"discussion"`
+	// Public by default.
+	Visibility RollVisibility `json:"visibility"`
+}
+
+func (*RollDiceCmd) Kind() CommandKind { return RollDiceKind }
+
+type RevealRollCmd struct {
+	// Id of the DiceRolled event.
+	Roll string `json:"roll"`
+}
+
+func (*RevealRollCmd) Kind() CommandKind { return RevealRollKind }
+
+// StartDiceSessionCmd makes the next rolls provably fair, until the session ends.
+type StartDiceSessionCmd struct{}
+
+func (*StartDiceSessionCmd) Kind() CommandKind { return StartDiceSessionKind }
+
+// AddClientSeedCmd sets the seed of the player for the next rolls of the dice session.
+type AddClientSeedCmd struct {
+	Seed string `json:"seed"`
+}
+
+func (*AddClientSeedCmd) Kind() CommandKind { return AddClientSeedKind }
+
+// EndDiceSessionCmd ends the dice session and reveals its server seed.
+type EndDiceSessionCmd struct{}
+
+func (*EndDiceSessionCmd) Kind() CommandKind { return EndDiceSessionKind }
+
+// SearchTablesQuery filters the tables of the authenticated user.
+type SearchTablesQuery struct {
+	Name string `json:"name"`
+	// Lists the archived tables instead of the active ones.
+	Archived bool `json:"archived"`
+	// Lists the tables of the trash of the owner instead of the active ones.
+	Deleted bool `json:"deleted"`
+	// Lists only the templates.
+	Templates bool `json:"templates"`
+}
+
+// LobbyQuery filters the discoverable tables.
+type LobbyQuery struct {
+	Text       string   `json:"text"`
+	GameSystem string   `json:"gameSystem"`
+	Language   string   `json:"language"`
+	Tags       []string `json:"tags"`
+	// Only the tables with free seats.
+	FreeSeats bool `json:"freeSeats"`
+	Limit     int  `json:"limit"`
+	Offset    int  `json:"offset"`
+}
+
+// DiceStatsQuery filters the rolls of the dice statistics.
+type DiceStatsQuery struct {
+	// Only the rolls of a dice session.
+	Session string     `json:"session"`
+	From    *time.Time `json:"from"`
+	To      *time.Time `json:"to"`
+}
+
+type StartEncounterCmd struct {
+	Name string `json:"name"`
+	// Dice expression of the rolled initiatives, `1d20` by default.
+	InitiativeDice string `json:"initiativeDice"`
+}
+
+func (*StartEncounterCmd) Kind() CommandKind { return StartEncounterKind }
+
+// AddCombatantCmd adds a character, or a NPC by its name, to the encounter.
+type AddCombatantCmd struct {
+	Character string `json:"character"`
+	// Name of a NPC without character, the name of the character by default.
+	Name string `json:"name"`
+	// Hidden to the players, the combatants of hidden characters are always hidden.
+	Hidden bool `json:"hidden"`
+	// Entered initiative, rolled with the initiative dice when missing.
+	Initiative *int `json:"initiative"`
+	// Added to the rolled initiative, the `initiative` derived field of the character by default.
+	Modifier *int `json:"modifier"`
+}
+
+func (*AddCombatantCmd) Kind() CommandKind { return AddCombatantKind }
+
+type RemoveCombatantCmd struct {
+	Combatant string `json:"combatant"`
+}
+
+func (*RemoveCombatantCmd) Kind() CommandKind { return RemoveCombatantKind }
+
+// NextTurnCmd ends the current turn, the players end only the turns of their combatants.
+type NextTurnCmd struct{}
+
+func (*NextTurnCmd) Kind() CommandKind { return NextTurnKind }
+
+type PreviousTurnCmd struct{}
+
+func (*PreviousTurnCmd) Kind() CommandKind { return PreviousTurnKind }
+
+// DelayTurnCmd ends the turn of the current combatant, who acts later in the round.
+type DelayTurnCmd struct {
+	Combatant string `json:"combatant"`
+}
+
+func (*DelayTurnCmd) Kind() CommandKind { return DelayTurnKind }
+
+// ReadyActionCmd ends the turn of the current combatant, who acts when the trigger happens.
+type ReadyActionCmd struct {
+	Combatant string `json:"combatant"`
+	// Like `when the goblin opens the door`.
+	Trigger string `json:"trigger"`
+}
+
+func (*ReadyActionCmd) Kind() CommandKind { return ReadyActionKind }
+
+// ActNowCmd makes a delaying or ready combatant act before the current one, its initiative changes accordingly.
+type ActNowCmd struct {
+	Combatant string `json:"combatant"`
+}
+
+func (*ActNowCmd) Kind() CommandKind { return ActNowKind }
+
+type EndEncounterCmd struct{}
+
+func (*EndEncounterCmd) Kind() CommandKind { return EndEncounterKind }
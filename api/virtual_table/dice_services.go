@@ -0,0 +1,186 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+	"time"
+)
+
+type RollVisibility string
+
+const (
+	PublicRoll RollVisibility = "public"
+	// Shown to the roller and the masters.
+	MastersRoll RollVisibility = "gm"
+	// Shown to the masters only, not even to the roller.
+	BlindRoll RollVisibility = "blind"
+	// Shown to the roller only.
+	SelfRoll RollVisibility = "self"
+)
+
+// Chat commands rolling dice, like `/roll 1d20+5 # attack`.
+var rollChatCommands = map[string]RollVisibility{
+	"/roll":      PublicRoll,
+	"/gmroll":    MastersRoll,
+	"/blindroll": BlindRoll,
+	"/selfroll":  SelfRoll,
+}
+
+// rollCommand reads the roll chat commands, ok is false for the other messages.
+func rollCommand(message string) (cmd *RollDiceCmd, ok bool) {
+	message = strings.TrimSpace(message)
+	name := strings.SplitN(message, " ", 2)[0]
+	visibility, ok := rollChatCommands[name]
+	if !ok {
+		return nil, false
+	}
+	cmd = &RollDiceCmd{Expression: strings.TrimPrefix(message, name), Visibility: visibility}
+	if i := strings.Index(cmd.Expression, "#"); i >= 0 {
+		cmd.Label = strings.TrimSpace(cmd.Expression[i+1:])
+		cmd.Expression = cmd.Expression[:i]
+	}
+	cmd.Expression = strings.TrimSpace(cmd.Expression)
+	return cmd, true
+}
+
+// blinded hides the dice of a blind roll to its roller.
+func (e *DiceRolled) blinded() *DiceRolled {
+	res := *e
+	res.Roll = dice.Result{Expression: e.Roll.Expression, Groups: []dice.Group{}}
+	return &res
+}
+
+// publicRollAudience returns the users who see a public roll in the discussion, the whole table when empty.
+func (t *Table) publicRollAudience(discussion string) ([]string, error) {
+	if discussion == "" {
+		return []string{"*"}, nil
+	}
+	d := t.Discussion(discussion)
+	if d == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("discussion %s not found", discussion))
+	}
+	return d.Between, nil
+}
+
+func (s *tableServices) RollDice(table *Table, cmd *RollDiceCmd, ctx context.Context) (Event, error) {
+	return s.roll(table, cmd, nil, nil, ctx)
+}
+
+// roll rolls the dice of the command, expanded from the macro of the character when given.
+func (s *tableServices) roll(table *Table, cmd *RollDiceCmd, character *Character, macro *Macro, ctx context.Context) (Event, error) {
+	user := app_context.GetAuthUser(ctx)
+	audience, err := table.publicRollAudience(cmd.Discussion)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Discussion != "" {
+		if !table.Includes(audience, user) {
+			return nil, lib.HttpForbidden(fmt.Errorf("not allowed to roll in discussion %s", cmd.Discussion))
+		}
+		if until := table.MutedUntil(user, cmd.Discussion, time.Now()); until != nil {
+			return nil, lib.HttpForbidden(fmt.Errorf("muted in discussion %s until %s", cmd.Discussion, until.Format(time.RFC3339)))
+		}
+	}
+	visibility := cmd.Visibility
+	switch visibility {
+	case "", PublicRoll:
+		visibility = PublicRoll
+	case MastersRoll:
+		audience = []string{user, MastersAudience}
+	case BlindRoll:
+		audience = []string{MastersAudience}
+	case SelfRoll:
+		audience = []string{user}
+	default:
+		return nil, lib.HttpBadRequest(fmt.Errorf("invalid visibility %s", visibility))
+	}
+	expression, err := dice.Parse(cmd.Expression)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	src, fair, err := s.fairSource(table, ctx)
+	if err != nil {
+		return nil, err
+	}
+	roll, err := expression.Roll(src)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+
+	evt := &DiceRolled{EventBase: NewEventBase(table.Id, audience, user), Player: user, Discussion: cmd.Discussion, Label: cmd.Label, Roll: *roll, Visibility: visibility, Fair: fair}
+	if macro != nil {
+		evt.Character, evt.Macro = character.Id, macro.Name
+	}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	if !table.Includes(audience, user) {
+		return evt.blinded(), nil
+	}
+	return evt, nil
+}
+
+// RevealRoll shows a hidden roll as if it was public, the journaled roll is widened too.
+func (s *tableServices) RevealRoll(table *Table, cmd *RevealRollCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	user := app_context.GetAuthUser(ctx)
+	id, err := primitive.ObjectIDFromHex(cmd.Roll)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	filter := bson.M{"_id": id, "tableId": table.Id, "_kind": DiceRolledType}
+	data := make(map[string]interface{})
+	err = db.Collection(journalCollectionName).FindOne(ctx, filter).Decode(&data)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("roll %s not found", cmd.Roll))
+	}
+	if err != nil {
+		return nil, err
+	}
+	e, err := ReadEvent(data, "bson")
+	if err != nil {
+		return nil, err
+	}
+	rolled := e.(*DiceRolled)
+	switch rolled.Visibility {
+	case "", PublicRoll:
+		return nil, lib.HttpConflict(fmt.Errorf("roll %s is already public", cmd.Roll))
+	case SelfRoll:
+		if rolled.Player != user {
+			return nil, lib.HttpForbidden(fmt.Errorf("only %s can reveal the roll %s", rolled.Player, cmd.Roll))
+		}
+	default:
+		if !table.IsMaster(user) {
+			return nil, lib.HttpForbidden(fmt.Errorf("only the masters can reveal the roll %s", cmd.Roll))
+		}
+	}
+	audience, err := table.publicRollAudience(rolled.Discussion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Widen the journaled roll with the reveal, unless it was revealed in the meantime.
+	filter["visibility"] = rolled.Visibility
+	widen := func(sc context.Context) error {
+		res, err := db.Collection(journalCollectionName).UpdateOne(sc, filter, bson.M{"$set": bson.M{"allowUsers": audience, "visibility": PublicRoll}})
+		if err != nil {
+			return err
+		}
+		if res.ModifiedCount == 0 {
+			return lib.HttpConflict(fmt.Errorf("roll %s is already public", cmd.Roll))
+		}
+		return nil
+	}
+	evt := &RollRevealed{EventBase: NewEventBase(table.Id, audience, user), RollId: cmd.Roll, Player: rolled.Player, Discussion: rolled.Discussion, Label: rolled.Label, Roll: rolled.Roll}
+	if err := s.commitWith(ctx, table.Id, evt, widen); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,79 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Read part
+
+// DiceStats sums up the journaled rolls of the table, only the ones the user can see are counted.
+func (s *tableServices) DiceStats(tableId string, query DiceStatsQuery, ctx context.Context) (*TableDiceStats, error) {
+	db := app_context.GetMongodb(ctx)
+	user := app_context.GetAuthUser(ctx)
+	table, err := s.loadTable(tableId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !table.IsMember(user) {
+		return nil, lib.HttpForbidden(fmt.Errorf("only the members of the table can see its dice statistics"))
+	}
+	filter := bson.M{"tableId": table.Id, "_kind": DiceRolledType}
+	if query.Session != "" {
+		filter["fair.session"] = query.Session
+	}
+	// The date of an event is the one of its id.
+	ids := bson.M{}
+	if query.From != nil {
+		ids["$gte"] = primitive.NewObjectIDFromTimestamp(*query.From)
+	}
+	if query.To != nil {
+		ids["$lt"] = primitive.NewObjectIDFromTimestamp(*query.To)
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, lib.HttpBadRequest(fmt.Errorf("from must be before to"))
+	}
+	if len(ids) > 0 {
+		filter["_id"] = ids
+	}
+	cursor, err := db.Collection(journalCollectionName).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	res := &TableDiceStats{Table: dice.NewTally(), Players: make(map[string]*dice.Tally)}
+	for cursor.Next(ctx) {
+		data := make(map[string]interface{})
+		if err := cursor.Decode(&data); err != nil {
+			return nil, err
+		}
+		e, err := ReadEvent(data, "bson")
+		if err != nil {
+			return nil, err
+		}
+		rolled := e.(*DiceRolled)
+		if !table.Includes(rolled.GetAllowUsers(), user) {
+			continue
+		}
+		res.Table.Add(&rolled.Roll)
+		if res.Players[rolled.Player] == nil {
+			res.Players[rolled.Player] = dice.NewTally()
+		}
+		res.Players[rolled.Player].Add(&rolled.Roll)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	res.Table.Summarize()
+	for _, tally := range res.Players {
+		tally.Summarize()
+	}
+	return res, nil
+}
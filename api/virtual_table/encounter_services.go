@@ -0,0 +1,435 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	defaultInitiativeDice = "1d20"
+	maxCombatants         = 100
+	maxTriggerLength      = 256
+	// Range of the last tie-break.
+	tieBreakRange = 1000000
+)
+
+// sortsBefore tells whether a combatant acts before another one: by initiative, then modifier, then the drawn tie-break.
+func (c *Combatant) sortsBefore(other *Combatant) bool {
+	if c.Initiative != other.Initiative {
+		return c.Initiative > other.Initiative
+	}
+	if c.Modifier != other.Modifier {
+		return c.Modifier > other.Modifier
+	}
+	return c.TieBreak > other.TieBreak
+}
+
+// insertionIndex returns where the combatant takes place in the turn order.
+func (e *Encounter) insertionIndex(c *Combatant) int {
+	for idx := range e.Combatants {
+		if c.sortsBefore(&e.Combatants[idx]) {
+			return idx
+		}
+	}
+	return len(e.Combatants)
+}
+
+func (e *Encounter) index(id string) int {
+	for idx := range e.Combatants {
+		if e.Combatants[idx].Id == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+// advance gives the turn to the next combatant, a new round starts after the last one.
+// The delayed turn or the readied action of the new current combatant expires.
+func (e *Encounter) advance() *Combatant {
+	idx := e.index(e.Current) + 1
+	if e.Round == 0 {
+		e.Round = 1
+	}
+	if idx >= len(e.Combatants) {
+		idx = 0
+		if e.Current != "" {
+			e.Round++
+		}
+	}
+	next := &e.Combatants[idx]
+	next.Status, next.Trigger = "", ""
+	e.Current = next.Id
+	return next
+}
+
+// isHidden tells whether the combatant is hidden, its id is then masked in the events for the users who are not masters.
+func (e *Encounter) isHidden(id string) bool {
+	c := e.Combatant(id)
+	return c != nil && c.Hidden
+}
+
+// visibleCombatants removes the combatants the user cannot see.
+func (t *Table) visibleCombatants(user string) {
+	if t.Encounter == nil {
+		return
+	}
+	combatants := make([]Combatant, 0, len(t.Encounter.Combatants))
+	for _, c := range t.Encounter.Combatants {
+		if t.Includes(c.Audience(), user) {
+			combatants = append(combatants, c)
+		}
+	}
+	t.Encounter.Combatants = combatants
+}
+
+func activeEncounter(table *Table) (*Encounter, error) {
+	if table.Encounter == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("no encounter in progress"))
+	}
+	return table.Encounter, nil
+}
+
+// controlledCombatant returns the combatant if the authenticated user acts for it: the masters act for any
+// combatant, the players only for their own ones.
+func controlledCombatant(table *Table, id string, ctx context.Context) (*Combatant, error) {
+	user := app_context.GetAuthUser(ctx)
+	encounter, err := activeEncounter(table)
+	if err != nil {
+		return nil, err
+	}
+	combatant := encounter.Combatant(id)
+	if combatant == nil || !table.Includes(combatant.Audience(), user) {
+		return nil, lib.HttpNotFound(fmt.Errorf("combatant %s not found", id))
+	}
+	if !table.IsMaster(user) && combatant.Player != user {
+		return nil, lib.HttpForbidden(fmt.Errorf("combatant %s is not yours", id))
+	}
+	return combatant, nil
+}
+
+// turnUpdate saves the turn of the encounter, with the status of the new current combatant cleared.
+func turnUpdate(encounter *Encounter, set bson.M) (bson.M, []interface{}) {
+	set["encounter.round"] = encounter.Round
+	set["encounter.current"] = encounter.Current
+	set["encounter.combatants.$[next].status"] = ""
+	set["encounter.combatants.$[next].trigger"] = ""
+	return bson.M{"$set": set}, []interface{}{bson.M{"next.id": encounter.Current}}
+}
+
+// Commands.
+
+func (s *tableServices) StartEncounter(table *Table, cmd *StartEncounterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if table.Encounter != nil {
+		return nil, lib.HttpConflict(fmt.Errorf("an encounter is already in progress"))
+	}
+	initiativeDice := strings.TrimSpace(cmd.InitiativeDice)
+	if initiativeDice == "" {
+		initiativeDice = defaultInitiativeDice
+	}
+	if _, err := dice.Parse(initiativeDice); err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	encounter := Encounter{Id: uuid.New().String(), Name: cmd.Name, InitiativeDice: initiativeDice, Combatants: []Combatant{}, StartedAt: time.Now()}
+
+	evt := &EncounterStarted{EventBase: NewEventBase(table.Id, []string{"*"}, by), Encounter: encounter}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"encounter": encounter}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// AddCombatant adds a character, or for the masters a NPC by its name, with an entered or a rolled initiative.
+func (s *tableServices) AddCombatant(table *Table, cmd *AddCombatantCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	encounter, err := activeEncounter(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(encounter.Combatants) >= maxCombatants {
+		return nil, lib.HttpBadRequest(fmt.Errorf("an encounter has at most %d combatants", maxCombatants))
+	}
+	if cmd.Hidden && !table.IsMaster(by) {
+		return nil, lib.HttpForbidden(fmt.Errorf("only the masters hide combatants"))
+	}
+	combatant := Combatant{Id: uuid.New().String(), Name: cmd.Name, Hidden: cmd.Hidden}
+	if cmd.Character != "" {
+		character, err := editableCharacter(table, cmd.Character, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if character.Retired {
+			return nil, lib.HttpConflict(fmt.Errorf("character %s is retired", character.Id))
+		}
+		for _, c := range encounter.Combatants {
+			if c.Character == character.Id {
+				return nil, lib.HttpConflict(fmt.Errorf("character %s is already in the encounter", character.Id))
+			}
+		}
+		combatant.Character, combatant.Player = character.Id, character.Player
+		combatant.Hidden = combatant.Hidden || character.Hidden
+		if combatant.Name == "" {
+			combatant.Name = character.Name
+		}
+		combatant.Modifier = int(math.Floor(character.Derived["initiative"]))
+	} else {
+		if !table.IsMaster(by) {
+			return nil, lib.HttpForbidden(fmt.Errorf("only the masters add NPCs without character"))
+		}
+		if combatant.Name == "" {
+			return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+		}
+	}
+	if cmd.Modifier != nil {
+		combatant.Modifier = *cmd.Modifier
+	}
+	if cmd.Initiative != nil {
+		combatant.Initiative = *cmd.Initiative
+	} else {
+		roll, err := dice.Roll(encounter.InitiativeDice, dice.CryptoSource)
+		if err != nil {
+			return nil, lib.HttpBadRequest(err)
+		}
+		combatant.Roll = roll
+		combatant.Initiative = roll.Total + combatant.Modifier
+	}
+	combatant.TieBreak = dice.CryptoSource.Intn(tieBreakRange)
+
+	// Pushed at its place, so that the combatants added at the same time are all kept.
+	evt := &CombatantAdded{EventBase: NewEventBase(table.Id, combatant.Audience(), by), Combatant: combatant}
+	update := bson.M{"$push": bson.M{"encounter.combatants": bson.M{"$each": []Combatant{combatant}, "$position": encounter.insertionIndex(&combatant)}}}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// RemoveCombatant removes a combatant from the turn order, the turn moves on when it is the current one.
+func (s *tableServices) RemoveCombatant(table *Table, cmd *RemoveCombatantCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	combatant, err := controlledCombatant(table, cmd.Combatant, ctx)
+	if err != nil {
+		return nil, err
+	}
+	encounter := table.Encounter
+	removed := *combatant
+	// Pulled, so that the combatants added meanwhile are kept.
+	updates := []tableUpdate{{update: bson.M{"$pull": bson.M{"encounter.combatants": bson.M{"id": removed.Id}}}}}
+	if encounter.Current == removed.Id {
+		if len(encounter.Combatants) == 1 {
+			encounter.Current = ""
+			updates = append(updates, tableUpdate{update: bson.M{"$set": bson.M{"encounter.current": ""}}})
+		} else {
+			encounter.advance()
+			update, arrayFilters := turnUpdate(encounter, bson.M{})
+			updates = append(updates, tableUpdate{update: update, arrayFilters: arrayFilters})
+		}
+	}
+
+	evt := &CombatantRemoved{
+		EventBase:       NewEventBase(table.Id, []string{"*"}, by),
+		Combatant:       removed.Id,
+		HiddenCombatant: removed.Hidden,
+		Round:           encounter.Round,
+		Current:         encounter.Current,
+		HiddenCurrent:   encounter.isHidden(encounter.Current),
+	}
+	if err := s.commitUpdates(ctx, table.Id, evt, updates...); err != nil {
+		return nil, err
+	}
+	return visibleEvent(evt, table.IsMaster(by)), nil
+}
+
+// NextTurn ends the current turn, the first one starts the first round.
+func (s *tableServices) NextTurn(table *Table, cmd *NextTurnCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	encounter, err := activeEncounter(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(encounter.Combatants) == 0 {
+		return nil, lib.HttpConflict(fmt.Errorf("no combatant in the encounter"))
+	}
+	if !table.IsMaster(by) {
+		current := encounter.Combatant(encounter.Current)
+		if current == nil || current.Player != by {
+			return nil, lib.HttpForbidden(fmt.Errorf("only the masters end the turns of the other combatants"))
+		}
+	}
+	encounter.advance()
+
+	evt := &TurnChanged{
+		EventBase:     NewEventBase(table.Id, []string{"*"}, by),
+		Action:        NextTurnAction,
+		Round:         encounter.Round,
+		Current:       encounter.Current,
+		HiddenCurrent: encounter.isHidden(encounter.Current),
+	}
+	update, arrayFilters := turnUpdate(encounter, bson.M{})
+	if err := s.commit(ctx, table.Id, evt, update, arrayFilters...); err != nil {
+		return nil, err
+	}
+	return visibleEvent(evt, table.IsMaster(by)), nil
+}
+
+// PreviousTurn gives the turn back to the previous combatant, the expired delays and readied actions are not restored.
+func (s *tableServices) PreviousTurn(table *Table, cmd *PreviousTurnCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	encounter, err := activeEncounter(table)
+	if err != nil {
+		return nil, err
+	}
+	idx := encounter.index(encounter.Current)
+	if idx < 0 || (encounter.Round <= 1 && idx == 0) {
+		return nil, lib.HttpConflict(fmt.Errorf("no previous turn"))
+	}
+	if idx == 0 {
+		idx = len(encounter.Combatants)
+		encounter.Round--
+	}
+	encounter.Current = encounter.Combatants[idx-1].Id
+
+	evt := &TurnChanged{
+		EventBase:     NewEventBase(table.Id, []string{"*"}, by),
+		Action:        PreviousTurnAction,
+		Round:         encounter.Round,
+		Current:       encounter.Current,
+		HiddenCurrent: encounter.isHidden(encounter.Current),
+	}
+	update := bson.M{"$set": bson.M{"encounter.round": encounter.Round, "encounter.current": encounter.Current}}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return visibleEvent(evt, table.IsMaster(by)), nil
+}
+
+// postponeTurn ends the turn of the current combatant with a delay or a readied action.
+func (s *tableServices) postponeTurn(table *Table, id string, action TurnAction, status CombatantStatus, trigger string, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	combatant, err := controlledCombatant(table, id, ctx)
+	if err != nil {
+		return nil, err
+	}
+	encounter := table.Encounter
+	if encounter.Current != combatant.Id {
+		return nil, lib.HttpConflict(fmt.Errorf("it is not the turn of combatant %s", combatant.Id))
+	}
+	if len(encounter.Combatants) < 2 {
+		return nil, lib.HttpConflict(fmt.Errorf("no other combatant to act before %s", combatant.Id))
+	}
+	combatant.Status, combatant.Trigger = status, trigger
+	postponed := *combatant
+	encounter.advance()
+
+	evt := &TurnChanged{
+		EventBase:       NewEventBase(table.Id, []string{"*"}, by),
+		Action:          action,
+		Combatant:       postponed.Id,
+		HiddenCombatant: postponed.Hidden,
+		Trigger:         trigger,
+		Round:           encounter.Round,
+		Current:         encounter.Current,
+		HiddenCurrent:   encounter.isHidden(encounter.Current),
+	}
+	update, arrayFilters := turnUpdate(encounter, bson.M{
+		"encounter.combatants.$[postponed].status":  status,
+		"encounter.combatants.$[postponed].trigger": trigger,
+	})
+	arrayFilters = append(arrayFilters, bson.M{"postponed.id": postponed.Id})
+	if err := s.commit(ctx, table.Id, evt, update, arrayFilters...); err != nil {
+		return nil, err
+	}
+	return visibleEvent(evt, table.IsMaster(by)), nil
+}
+
+func (s *tableServices) DelayTurn(table *Table, cmd *DelayTurnCmd, ctx context.Context) (Event, error) {
+	return s.postponeTurn(table, cmd.Combatant, DelayTurnAction, DelayingCombatant, "", ctx)
+}
+
+func (s *tableServices) ReadyAction(table *Table, cmd *ReadyActionCmd, ctx context.Context) (Event, error) {
+	trigger := strings.TrimSpace(cmd.Trigger)
+	if trigger == "" || len(trigger) > maxTriggerLength {
+		return nil, lib.HttpBadRequest(fmt.Errorf("trigger must have between 1 and %d characters", maxTriggerLength))
+	}
+	return s.postponeTurn(table, cmd.Combatant, ReadyAction, ReadyCombatant, trigger, ctx)
+}
+
+// ActNow makes a delaying or ready combatant act before the current one, with the same initiative unless the current
+// one is hidden and the acting one is not. The interrupted combatant takes its turn next.
+func (s *tableServices) ActNow(table *Table, cmd *ActNowCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	combatant, err := controlledCombatant(table, cmd.Combatant, ctx)
+	if err != nil {
+		return nil, err
+	}
+	encounter := table.Encounter
+	if combatant.Status != DelayingCombatant && combatant.Status != ReadyCombatant {
+		return nil, lib.HttpConflict(fmt.Errorf("combatant %s is neither delaying nor ready", combatant.Id))
+	}
+	current := encounter.Combatant(encounter.Current)
+	if current == nil {
+		return nil, lib.HttpConflict(fmt.Errorf("no turn in progress"))
+	}
+	acting := *combatant
+	acting.Status, acting.Trigger = "", ""
+	// The initiative of a hidden combatant is not revealed through a visible one.
+	if !current.Hidden || acting.Hidden {
+		acting.Initiative = current.Initiative
+	}
+	// Moved just before the interrupted combatant, the order of the list prevails over the initiatives.
+	position := 0
+	for _, c := range encounter.Combatants {
+		if c.Id == current.Id {
+			break
+		}
+		if c.Id != acting.Id {
+			position++
+		}
+	}
+	encounter.Current = acting.Id
+
+	evt := &TurnChanged{
+		EventBase:       NewEventBase(table.Id, []string{"*"}, by),
+		Action:          ActNowAction,
+		Combatant:       acting.Id,
+		HiddenCombatant: acting.Hidden,
+		Initiative:      acting.Initiative,
+		Round:           encounter.Round,
+		Current:         encounter.Current,
+		HiddenCurrent:   acting.Hidden,
+	}
+	// Pulled and pushed back at its place, so that the combatants added meanwhile are kept.
+	updates := []tableUpdate{
+		{update: bson.M{"$pull": bson.M{"encounter.combatants": bson.M{"id": acting.Id}}}},
+		{update: bson.M{
+			"$push": bson.M{"encounter.combatants": bson.M{"$each": []Combatant{acting}, "$position": position}},
+			"$set":  bson.M{"encounter.current": encounter.Current},
+		}},
+	}
+	if err := s.commitUpdates(ctx, table.Id, evt, updates...); err != nil {
+		return nil, err
+	}
+	return visibleEvent(evt, table.IsMaster(by)), nil
+}
+
+func (s *tableServices) EndEncounter(table *Table, cmd *EndEncounterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	encounter, err := activeEncounter(table)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := &EncounterEnded{EventBase: NewEventBase(table.Id, []string{"*"}, by), Encounter: encounter.Id, Rounds: encounter.Round}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$unset": bson.M{"encounter": ""}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
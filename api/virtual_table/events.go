@@ -3,6 +3,7 @@ package virtual_table
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/dice"
 	"github.com/rpg-tools/toolbox-services/lib"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,6 +20,46 @@ const (
 	PlayerWritingMessageType     EventType = "evt:player-writing-message"
 	PlayerStopWritingMessageType EventType = "evt:player-stop-writing-message"
 	PlayerSentMessageType        EventType = "evt:player-sent-message"
+	BotInvitedType               EventType = "evt:bot-invited"
+	BotRemovedType               EventType = "evt:bot-removed"
+	SettingsUpdatedType          EventType = "evt:settings-updated"
+	InviteCreatedType            EventType = "evt:invite-created"
+	InviteRevokedType            EventType = "evt:invite-revoked"
+	LobbyUpdatedType             EventType = "evt:lobby-updated"
+	JoinRequestedType            EventType = "evt:join-requested"
+	JoinRequestApprovedType      EventType = "evt:join-request-approved"
+	JoinRequestRejectedType      EventType = "evt:join-request-rejected"
+	CharacterCreatedType         EventType = "evt:character-created"
+	CharacterUpdatedType         EventType = "evt:character-updated"
+	CharacterAssignedType        EventType = "evt:character-assigned"
+	CharacterRetiredType         EventType = "evt:character-retired"
+	CharacterDeletedType         EventType = "evt:character-deleted"
+	MacroSavedType               EventType = "evt:macro-saved"
+	MacroDeletedType             EventType = "evt:macro-deleted"
+	DiscussionUpdatedType        EventType = "evt:discussion-updated"
+	HandoutCreatedType           EventType = "evt:handout-created"
+	HandoutSharedType            EventType = "evt:handout-shared"
+	HandoutDeletedType           EventType = "evt:handout-deleted"
+	TableArchivedType            EventType = "evt:table-archived"
+	TableDeletedType             EventType = "evt:table-deleted"
+	TableRestoredType            EventType = "evt:table-restored"
+	OwnershipTransferredType     EventType = "evt:ownership-transferred"
+	CoMasterAddedType            EventType = "evt:co-master-added"
+	CoMasterRemovedType          EventType = "evt:co-master-removed"
+	PlayerKickedType             EventType = "evt:player-kicked"
+	PlayerBannedType             EventType = "evt:player-banned"
+	PlayerUnbannedType           EventType = "evt:player-unbanned"
+	PlayerMutedType              EventType = "evt:player-muted"
+	DiceRolledType               EventType = "evt:dice-rolled"
+	RollRevealedType             EventType = "evt:roll-revealed"
+	DiceSessionStartedType       EventType = "evt:dice-session-started"
+	ClientSeedAddedType          EventType = "evt:client-seed-added"
+	DiceSessionEndedType         EventType = "evt:dice-session-ended"
+	EncounterStartedType         EventType = "evt:encounter-started"
+	CombatantAddedType           EventType = "evt:combatant-added"
+	CombatantRemovedType         EventType = "evt:combatant-removed"
+	TurnChangedType              EventType = "evt:turn-changed"
+	EncounterEndedType           EventType = "evt:encounter-ended"
 )
 
 var eventsSupplierByKind map[EventType]func() Event
@@ -32,6 +73,46 @@ func init() {
 		PlayerWritingMessageType:     func() Event { return &PlayerWritingMessage{} },
 		PlayerStopWritingMessageType: func() Event { return &PlayerStopWritingMessage{} },
 		PlayerSentMessageType:        func() Event { return &PlayerSentMessage{} },
+		BotInvitedType:               func() Event { return &BotInvited{} },
+		BotRemovedType:               func() Event { return &BotRemoved{} },
+		SettingsUpdatedType:          func() Event { return &SettingsUpdated{} },
+		InviteCreatedType:            func() Event { return &InviteCreated{} },
+		InviteRevokedType:            func() Event { return &InviteRevoked{} },
+		LobbyUpdatedType:             func() Event { return &LobbyUpdated{} },
+		JoinRequestedType:            func() Event { return &JoinRequested{} },
+		JoinRequestApprovedType:      func() Event { return &JoinRequestApproved{} },
+		JoinRequestRejectedType:      func() Event { return &JoinRequestRejected{} },
+		CharacterCreatedType:         func() Event { return &CharacterCreated{} },
+		CharacterUpdatedType:         func() Event { return &CharacterUpdated{} },
+		CharacterAssignedType:        func() Event { return &CharacterAssigned{} },
+		CharacterRetiredType:         func() Event { return &CharacterRetired{} },
+		CharacterDeletedType:         func() Event { return &CharacterDeleted{} },
+		MacroSavedType:               func() Event { return &MacroSaved{} },
+		MacroDeletedType:             func() Event { return &MacroDeleted{} },
+		DiscussionUpdatedType:        func() Event { return &DiscussionUpdated{} },
+		HandoutCreatedType:           func() Event { return &HandoutCreated{} },
+		HandoutSharedType:            func() Event { return &HandoutShared{} },
+		HandoutDeletedType:           func() Event { return &HandoutDeleted{} },
+		TableArchivedType:            func() Event { return &TableArchived{} },
+		TableDeletedType:             func() Event { return &TableDeleted{} },
+		TableRestoredType:            func() Event { return &TableRestored{} },
+		OwnershipTransferredType:     func() Event { return &OwnershipTransferred{} },
+		CoMasterAddedType:            func() Event { return &CoMasterAdded{} },
+		CoMasterRemovedType:          func() Event { return &CoMasterRemoved{} },
+		PlayerKickedType:             func() Event { return &PlayerKicked{} },
+		PlayerBannedType:             func() Event { return &PlayerBanned{} },
+		PlayerUnbannedType:           func() Event { return &PlayerUnbanned{} },
+		PlayerMutedType:              func() Event { return &PlayerMuted{} },
+		DiceRolledType:               func() Event { return &DiceRolled{} },
+		RollRevealedType:             func() Event { return &RollRevealed{} },
+		DiceSessionStartedType:       func() Event { return &DiceSessionStarted{} },
+		ClientSeedAddedType:          func() Event { return &ClientSeedAdded{} },
+		DiceSessionEndedType:         func() Event { return &DiceSessionEnded{} },
+		EncounterStartedType:         func() Event { return &EncounterStarted{} },
+		CombatantAddedType:           func() Event { return &CombatantAdded{} },
+		CombatantRemovedType:         func() Event { return &CombatantRemoved{} },
+		TurnChangedType:              func() Event { return &TurnChanged{} },
+		EncounterEndedType:           func() Event { return &EncounterEnded{} },
 	}
 }
 
@@ -44,6 +125,20 @@ type Event interface {
 	Kind() EventType
 }
 
+// maskedEvent is implemented by the events with details only the masters see, like the hidden combatants.
+type maskedEvent interface {
+	// Masked returns the event as seen by the users who are not masters.
+	Masked() Event
+}
+
+// visibleEvent returns the event as seen by a user.
+func visibleEvent(evt Event, master bool) Event {
+	if m, ok := evt.(maskedEvent); ok && !master {
+		return m.Masked()
+	}
+	return evt
+}
+
 type EventBase struct {
 	Id         primitive.ObjectID `json:"id" bson:"_id"`
 	TableId    primitive.ObjectID `json:"tableId" bson:"tableId"`
@@ -134,6 +229,8 @@ func ReadEventBson(data []byte) (Event, error) {
 type TableCreated struct {
 	EventBase
 	Name string `json:"name" bson:"name"`
+	// Id of the table it was cloned from, if any.
+	ClonedFrom string `json:"clonedFrom,omitempty" bson:"clonedFrom,omitempty"`
 }
 
 func (*TableCreated) Kind() EventType                { return TableCreatedType }
@@ -143,6 +240,7 @@ func (e *TableCreated) MarshalJSON() ([]byte, error) { return WriteEventJson(e)
 type PlayerJoint struct {
 	EventBase
 	Player string `json:"player" bson:"player"`
+	Role   Role   `json:"role" bson:"role"`
 }
 
 func (*PlayerJoint) Kind() EventType                { return PlayerJointType }
@@ -197,3 +295,455 @@ type PlayerSentMessage struct {
 func (*PlayerSentMessage) Kind() EventType                { return PlayerSentMessageType }
 func (e *PlayerSentMessage) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
 func (e *PlayerSentMessage) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type BotInvited struct {
+	EventBase
+	Bot      string        `json:"bot" bson:"bot"`
+	Commands []CommandKind `json:"commands" bson:"commands"`
+}
+
+func (*BotInvited) Kind() EventType                { return BotInvitedType }
+func (e *BotInvited) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *BotInvited) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type BotRemoved struct {
+	EventBase
+	Bot string `json:"bot" bson:"bot"`
+}
+
+func (*BotRemoved) Kind() EventType                { return BotRemovedType }
+func (e *BotRemoved) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *BotRemoved) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type SettingsUpdated struct {
+	EventBase
+	Settings TableSettings `json:"settings" bson:"settings"`
+}
+
+func (*SettingsUpdated) Kind() EventType                { return SettingsUpdatedType }
+func (e *SettingsUpdated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *SettingsUpdated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type InviteCreated struct {
+	EventBase
+	Invite    string    `json:"invite" bson:"invite"`
+	Token     string    `json:"token" bson:"token"`
+	Role      Role      `json:"role" bson:"role"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+	MaxUses   int       `json:"maxUses" bson:"maxUses"`
+}
+
+func (*InviteCreated) Kind() EventType                { return InviteCreatedType }
+func (e *InviteCreated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *InviteCreated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type InviteRevoked struct {
+	EventBase
+	Invite string `json:"invite" bson:"invite"`
+}
+
+func (*InviteRevoked) Kind() EventType                { return InviteRevokedType }
+func (e *InviteRevoked) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *InviteRevoked) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type JoinRequested struct {
+	EventBase
+	Request string `json:"request" bson:"request"`
+	Player  string `json:"player" bson:"player"`
+	Message string `json:"message" bson:"message"`
+}
+
+func (*JoinRequested) Kind() EventType                { return JoinRequestedType }
+func (e *JoinRequested) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *JoinRequested) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type JoinRequestApproved struct {
+	EventBase
+	Request string `json:"request" bson:"request"`
+	Player  string `json:"player" bson:"player"`
+}
+
+func (*JoinRequestApproved) Kind() EventType                { return JoinRequestApprovedType }
+func (e *JoinRequestApproved) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *JoinRequestApproved) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type JoinRequestRejected struct {
+	EventBase
+	Request string `json:"request" bson:"request"`
+	Player  string `json:"player" bson:"player"`
+	Reason  string `json:"reason" bson:"reason"`
+}
+
+func (*JoinRequestRejected) Kind() EventType                { return JoinRequestRejectedType }
+func (e *JoinRequestRejected) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *JoinRequestRejected) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type LobbyUpdated struct {
+	EventBase
+	Lobby Lobby `json:"lobby" bson:"lobby"`
+}
+
+func (*LobbyUpdated) Kind() EventType                { return LobbyUpdatedType }
+func (e *LobbyUpdated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *LobbyUpdated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CharacterCreated struct {
+	EventBase
+	Character Character `json:"character" bson:"character"`
+}
+
+func (*CharacterCreated) Kind() EventType                { return CharacterCreatedType }
+func (e *CharacterCreated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CharacterCreated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CharacterUpdated struct {
+	EventBase
+	Character Character `json:"character" bson:"character"`
+}
+
+func (*CharacterUpdated) Kind() EventType                { return CharacterUpdatedType }
+func (e *CharacterUpdated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CharacterUpdated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CharacterAssigned struct {
+	EventBase
+	Character string `json:"character" bson:"character"`
+	Player    string `json:"player" bson:"player"`
+}
+
+func (*CharacterAssigned) Kind() EventType                { return CharacterAssignedType }
+func (e *CharacterAssigned) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CharacterAssigned) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CharacterRetired struct {
+	EventBase
+	Character string `json:"character" bson:"character"`
+}
+
+func (*CharacterRetired) Kind() EventType                { return CharacterRetiredType }
+func (e *CharacterRetired) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CharacterRetired) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CharacterDeleted struct {
+	EventBase
+	Character string `json:"character" bson:"character"`
+}
+
+func (*CharacterDeleted) Kind() EventType                { return CharacterDeletedType }
+func (e *CharacterDeleted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CharacterDeleted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type MacroSaved struct {
+	EventBase
+	Character string `json:"character" bson:"character"`
+	Macro     Macro  `json:"macro" bson:"macro"`
+}
+
+func (*MacroSaved) Kind() EventType                { return MacroSavedType }
+func (e *MacroSaved) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *MacroSaved) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type MacroDeleted struct {
+	EventBase
+	Character string `json:"character" bson:"character"`
+	Name      string `json:"name" bson:"name"`
+}
+
+func (*MacroDeleted) Kind() EventType                { return MacroDeletedType }
+func (e *MacroDeleted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *MacroDeleted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type DiscussionUpdated struct {
+	EventBase
+	Discussion string `json:"discussion" bson:"discussion"`
+	Template   bool   `json:"template" bson:"template"`
+}
+
+func (*DiscussionUpdated) Kind() EventType                { return DiscussionUpdatedType }
+func (e *DiscussionUpdated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *DiscussionUpdated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type HandoutCreated struct {
+	EventBase
+	Handout Handout `json:"handout" bson:"handout"`
+}
+
+func (*HandoutCreated) Kind() EventType                { return HandoutCreatedType }
+func (e *HandoutCreated) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *HandoutCreated) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type HandoutShared struct {
+	EventBase
+	Handout Handout `json:"handout" bson:"handout"`
+}
+
+func (*HandoutShared) Kind() EventType                { return HandoutSharedType }
+func (e *HandoutShared) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *HandoutShared) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type HandoutDeleted struct {
+	EventBase
+	Handout string `json:"handout" bson:"handout"`
+}
+
+func (*HandoutDeleted) Kind() EventType                { return HandoutDeletedType }
+func (e *HandoutDeleted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *HandoutDeleted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type TableArchived struct {
+	EventBase
+}
+
+func (*TableArchived) Kind() EventType                { return TableArchivedType }
+func (e *TableArchived) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *TableArchived) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type TableDeleted struct {
+	EventBase
+}
+
+func (*TableDeleted) Kind() EventType                { return TableDeletedType }
+func (e *TableDeleted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *TableDeleted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type TableRestored struct {
+	EventBase
+}
+
+func (*TableRestored) Kind() EventType                { return TableRestoredType }
+func (e *TableRestored) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *TableRestored) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type OwnershipTransferred struct {
+	EventBase
+	From string `json:"from" bson:"from"`
+	To   string `json:"to" bson:"to"`
+}
+
+func (*OwnershipTransferred) Kind() EventType                { return OwnershipTransferredType }
+func (e *OwnershipTransferred) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *OwnershipTransferred) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CoMasterAdded struct {
+	EventBase
+	Player string `json:"player" bson:"player"`
+}
+
+func (*CoMasterAdded) Kind() EventType                { return CoMasterAddedType }
+func (e *CoMasterAdded) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CoMasterAdded) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type CoMasterRemoved struct {
+	EventBase
+	Player string `json:"player" bson:"player"`
+}
+
+func (*CoMasterRemoved) Kind() EventType                { return CoMasterRemovedType }
+func (e *CoMasterRemoved) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CoMasterRemoved) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type PlayerKicked struct {
+	EventBase
+	Player string `json:"player" bson:"player"`
+}
+
+func (*PlayerKicked) Kind() EventType                { return PlayerKickedType }
+func (e *PlayerKicked) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *PlayerKicked) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type PlayerBanned struct {
+	EventBase
+	Player string `json:"player" bson:"player"`
+	Reason string `json:"reason" bson:"reason"`
+}
+
+func (*PlayerBanned) Kind() EventType                { return PlayerBannedType }
+func (e *PlayerBanned) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *PlayerBanned) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type PlayerUnbanned struct {
+	EventBase
+	Player string `json:"player" bson:"player"`
+}
+
+func (*PlayerUnbanned) Kind() EventType                { return PlayerUnbannedType }
+func (e *PlayerUnbanned) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *PlayerUnbanned) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type PlayerMuted struct {
+	EventBase
+	Player     string    `json:"player" bson:"player"`
+	Discussion string    `json:"discussion" bson:"discussion"`
+	Until      time.Time `json:"until" bson:"until"`
+}
+
+func (*PlayerMuted) Kind() EventType                { return PlayerMutedType }
+func (e *PlayerMuted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *PlayerMuted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type DiceRolled struct {
+	EventBase
+	Player     string      `json:"player" bson:"player"`
+	Discussion string      `json:"discussion" bson:"discussion"`
+	Label      string      `json:"label" bson:"label"`
+	Roll       dice.Result `json:"roll" bson:"roll"`
+	// Public once revealed.
+	Visibility RollVisibility `json:"visibility" bson:"visibility"`
+	// Seeds of the roll when rolled during a dice session.
+	Fair *FairRoll `json:"fair" bson:"fair"`
+	// Character and name of the macro rolled, the expression of the roll is the expanded one.
+	Character string `json:"character,omitempty" bson:"character,omitempty"`
+	Macro     string `json:"macro,omitempty" bson:"macro,omitempty"`
+}
+
+func (*DiceRolled) Kind() EventType                { return DiceRolledType }
+func (e *DiceRolled) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *DiceRolled) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+// RollRevealed shows a hidden roll to the audience of a public roll.
+type RollRevealed struct {
+	EventBase
+	// Id of the DiceRolled event.
+	RollId     string      `json:"rollId" bson:"rollId"`
+	Player     string      `json:"player" bson:"player"`
+	Discussion string      `json:"discussion" bson:"discussion"`
+	Label      string      `json:"label" bson:"label"`
+	Roll       dice.Result `json:"roll" bson:"roll"`
+}
+
+func (*RollRevealed) Kind() EventType                { return RollRevealedType }
+func (e *RollRevealed) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *RollRevealed) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type DiceSessionStarted struct {
+	EventBase
+	Session string `json:"session" bson:"session"`
+	// Commitment to the server seed, revealed at the end of the session.
+	ServerSeedHash string `json:"serverSeedHash" bson:"serverSeedHash"`
+}
+
+func (*DiceSessionStarted) Kind() EventType                { return DiceSessionStartedType }
+func (e *DiceSessionStarted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *DiceSessionStarted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type ClientSeedAdded struct {
+	EventBase
+	Session string `json:"session" bson:"session"`
+	Player  string `json:"player" bson:"player"`
+	Seed    string `json:"seed" bson:"seed"`
+}
+
+func (*ClientSeedAdded) Kind() EventType                { return ClientSeedAddedType }
+func (e *ClientSeedAdded) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *ClientSeedAdded) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type DiceSessionEnded struct {
+	EventBase
+	Session    string `json:"session" bson:"session"`
+	ServerSeed string `json:"serverSeed" bson:"serverSeed"`
+	// Number of rolls of the session.
+	Rolls int `json:"rolls" bson:"rolls"`
+}
+
+func (*DiceSessionEnded) Kind() EventType                { return DiceSessionEndedType }
+func (e *DiceSessionEnded) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *DiceSessionEnded) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type EncounterStarted struct {
+	EventBase
+	Encounter Encounter `json:"encounter" bson:"encounter"`
+}
+
+func (*EncounterStarted) Kind() EventType                { return EncounterStartedType }
+func (e *EncounterStarted) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *EncounterStarted) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+// CombatantAdded is sent to the users who see the combatant, its place in the turn order follows from
+// its initiative and tie-breaks.
+type CombatantAdded struct {
+	EventBase
+	Combatant Combatant `json:"combatant" bson:"combatant"`
+}
+
+func (*CombatantAdded) Kind() EventType                { return CombatantAddedType }
+func (e *CombatantAdded) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CombatantAdded) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+// CombatantRemoved is sent to the whole table, the ids of the hidden combatants are masked for the other users
+// than the masters.
+type CombatantRemoved struct {
+	EventBase
+	Combatant       string `json:"combatant" bson:"combatant"`
+	HiddenCombatant bool   `json:"hiddenCombatant,omitempty" bson:"hiddenCombatant,omitempty"`
+	// Turn after the removal, it moves on when the current combatant is removed.
+	Round         int    `json:"round" bson:"round"`
+	Current       string `json:"current" bson:"current"`
+	HiddenCurrent bool   `json:"hiddenCurrent,omitempty" bson:"hiddenCurrent,omitempty"`
+}
+
+func (e *CombatantRemoved) Masked() Event {
+	res := *e
+	if res.HiddenCombatant {
+		res.Combatant = ""
+	}
+	if res.HiddenCurrent {
+		res.Current = ""
+	}
+	return &res
+}
+
+func (*CombatantRemoved) Kind() EventType                { return CombatantRemovedType }
+func (e *CombatantRemoved) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *CombatantRemoved) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type TurnAction string
+
+const (
+	NextTurnAction     TurnAction = "next"
+	PreviousTurnAction TurnAction = "previous"
+	DelayTurnAction    TurnAction = "delay"
+	ReadyAction        TurnAction = "ready"
+	ActNowAction       TurnAction = "act-now"
+)
+
+// TurnChanged is sent to the whole table, the details of the hidden combatants are masked for the other users
+// than the masters.
+type TurnChanged struct {
+	EventBase
+	Action TurnAction `json:"action" bson:"action"`
+	// Combatant who delayed, readied or acted now.
+	Combatant       string `json:"combatant" bson:"combatant"`
+	HiddenCombatant bool   `json:"hiddenCombatant,omitempty" bson:"hiddenCombatant,omitempty"`
+	// Trigger of the readied action.
+	Trigger string `json:"trigger" bson:"trigger"`
+	// New initiative of the combatant who acted now.
+	Initiative    int    `json:"initiative" bson:"initiative"`
+	Round         int    `json:"round" bson:"round"`
+	Current       string `json:"current" bson:"current"`
+	HiddenCurrent bool   `json:"hiddenCurrent,omitempty" bson:"hiddenCurrent,omitempty"`
+}
+
+func (e *TurnChanged) Masked() Event {
+	res := *e
+	if res.HiddenCombatant {
+		res.Combatant, res.Trigger, res.Initiative = "", "", 0
+	}
+	if res.HiddenCurrent {
+		res.Current = ""
+	}
+	return &res
+}
+
+func (*TurnChanged) Kind() EventType                { return TurnChangedType }
+func (e *TurnChanged) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *TurnChanged) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
+
+type EncounterEnded struct {
+	EventBase
+	Encounter string `json:"encounter" bson:"encounter"`
+	Rounds    int    `json:"rounds" bson:"rounds"`
+}
+
+func (*EncounterEnded) Kind() EventType                { return EncounterEndedType }
+func (e *EncounterEnded) MarshalBSON() ([]byte, error) { return WriteEventBson(e) }
+func (e *EncounterEnded) MarshalJSON() ([]byte, error) { return WriteEventJson(e) }
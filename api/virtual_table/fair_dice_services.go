@@ -0,0 +1,213 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+	"time"
+)
+
+const (
+	diceSessionsCollectionName = "tables_dice_sessions"
+	maxClientSeedLength        = 128
+)
+
+// fairSource returns the source of the next roll of the dice session in progress, with the seeds to verify it.
+// The rolls are drawn from the system random generator when there is no session.
+func (s *tableServices) fairSource(table *Table, ctx context.Context) (dice.Source, *FairRoll, error) {
+	db := app_context.GetMongodb(ctx)
+	session := &DiceSession{}
+	err := db.Collection(diceSessionsCollectionName).FindOneAndUpdate(ctx,
+		bson.M{"tableId": table.Id, "endedAt": nil},
+		bson.M{"$inc": bson.M{"nonce": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(session)
+	if err == mongo.ErrNoDocuments {
+		return dice.CryptoSource, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	fair := &FairRoll{Session: session.Id.Hex(), ServerSeedHash: session.ServerSeedHash, ClientSeed: session.CombinedClientSeed(), Nonce: session.Nonce}
+	return dice.NewFairSource(session.ServerSeed, fair.ClientSeed, fair.Nonce), fair, nil
+}
+
+// Read part
+
+// VerifyRoll draws again a roll of a dice session, once the session ended and its server seed is revealed.
+func (s *tableServices) VerifyRoll(tableId string, rollId string, ctx context.Context) (*RollVerification, error) {
+	db := app_context.GetMongodb(ctx)
+	user := app_context.GetAuthUser(ctx)
+	table, err := s.loadTable(tableId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(rollId)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	data := make(map[string]interface{})
+	err = db.Collection(journalCollectionName).FindOne(ctx, bson.M{"_id": id, "tableId": table.Id, "_kind": DiceRolledType}).Decode(&data)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("roll %s not found", rollId))
+	}
+	if err != nil {
+		return nil, err
+	}
+	e, err := ReadEvent(data, "bson")
+	if err != nil {
+		return nil, err
+	}
+	rolled := e.(*DiceRolled)
+	if !table.Includes(rolled.GetAllowUsers(), user) {
+		return nil, lib.HttpNotFound(fmt.Errorf("roll %s not found", rollId))
+	}
+	if rolled.Fair == nil {
+		return nil, lib.HttpConflict(fmt.Errorf("roll %s was not rolled during a dice session", rollId))
+	}
+	sessionId, err := primitive.ObjectIDFromHex(rolled.Fair.Session)
+	if err != nil {
+		return nil, err
+	}
+	session := &DiceSession{}
+	err = db.Collection(diceSessionsCollectionName).FindOne(ctx, bson.M{"_id": sessionId, "tableId": table.Id}).Decode(session)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("dice session %s not found", rolled.Fair.Session))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.EndedAt == nil {
+		return nil, lib.HttpConflict(fmt.Errorf("the server seed of the dice session %s is revealed when the session ends", rolled.Fair.Session))
+	}
+
+	res := &RollVerification{Roll: rollId, FairRoll: *rolled.Fair, ServerSeed: session.ServerSeed}
+	if dice.HashSeed(session.ServerSeed) != rolled.Fair.ServerSeedHash {
+		res.Reason = "the server seed does not match its commitment"
+		return res, nil
+	}
+	expression, err := dice.Parse(rolled.Roll.Expression)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := expression.Roll(dice.NewFairSource(session.ServerSeed, rolled.Fair.ClientSeed, rolled.Fair.Nonce))
+	if err != nil {
+		return nil, err
+	}
+	res.Expected = *expected
+	res.Valid = reflect.DeepEqual(expected, &rolled.Roll)
+	if !res.Valid {
+		res.Reason = "the seeds do not draw the same dice"
+	}
+	return res, nil
+}
+
+// Commands.
+
+func (s *tableServices) StartDiceSession(table *Table, cmd *StartDiceSessionCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	count, err := db.Collection(diceSessionsCollectionName).CountDocuments(ctx, bson.M{"tableId": table.Id, "endedAt": nil})
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, lib.HttpConflict(fmt.Errorf("a dice session is already in progress"))
+	}
+	seed, err := dice.NewServerSeed()
+	if err != nil {
+		return nil, err
+	}
+	session := &DiceSession{Id: primitive.NewObjectID(), TableId: table.Id, ServerSeed: seed, ServerSeedHash: dice.HashSeed(seed), ClientSeeds: []ClientSeed{}, StartedAt: time.Now()}
+	// Counted first for the usual error, the unique index of the sessions in progress settles the concurrent starts.
+	if _, err := db.Collection(diceSessionsCollectionName).InsertOne(ctx, session); err != nil {
+		if isDuplicateKey(err) {
+			return nil, lib.HttpConflict(fmt.Errorf("a dice session is already in progress"))
+		}
+		return nil, err
+	}
+	evt := &DiceSessionStarted{EventBase: NewEventBase(table.Id, []string{"*"}, by), Session: session.Id.Hex(), ServerSeedHash: session.ServerSeedHash}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// AddClientSeed sets the seed of the player, it replaces the previous one.
+func (s *tableServices) AddClientSeed(table *Table, cmd *AddClientSeedCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	if cmd.Seed == "" || len(cmd.Seed) > maxClientSeedLength {
+		return nil, lib.HttpBadRequest(fmt.Errorf("seed must have between 1 and %d characters", maxClientSeedLength))
+	}
+	session := &DiceSession{}
+	err := db.Collection(diceSessionsCollectionName).FindOne(ctx, bson.M{"tableId": table.Id, "endedAt": nil}).Decode(session)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("no dice session in progress"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := db.Collection(diceSessionsCollectionName).UpdateOne(ctx,
+		bson.M{"_id": session.Id, "clientSeeds.player": by},
+		bson.M{"$set": bson.M{"clientSeeds.$.seed": cmd.Seed}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		_, err = db.Collection(diceSessionsCollectionName).UpdateOne(ctx,
+			bson.M{"_id": session.Id, "clientSeeds.player": bson.M{"$ne": by}},
+			bson.M{"$push": bson.M{"clientSeeds": &ClientSeed{Player: by, Seed: cmd.Seed}}},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	evt := &ClientSeedAdded{EventBase: NewEventBase(table.Id, []string{"*"}, by), Session: session.Id.Hex(), Player: by, Seed: cmd.Seed}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// EndDiceSession reveals the server seed, the rolls of the session can then be verified.
+func (s *tableServices) EndDiceSession(table *Table, cmd *EndDiceSessionCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	session := &DiceSession{}
+	err := db.Collection(diceSessionsCollectionName).FindOneAndUpdate(ctx,
+		bson.M{"tableId": table.Id, "endedAt": nil},
+		bson.M{"$set": bson.M{"endedAt": time.Now()}},
+	).Decode(session)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("no dice session in progress"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	evt := &DiceSessionEnded{EventBase: NewEventBase(table.Id, []string{"*"}, by), Session: session.Id.Hex(), ServerSeed: session.ServerSeed, Rolls: session.Nonce}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// isDuplicateKey tells whether the write failed on a unique index.
+func isDuplicateKey(err error) bool {
+	if e, ok := err.(mongo.WriteException); ok {
+		for _, we := range e.WriteErrors {
+			if we.Code == 11000 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,404 @@
+package virtual_table
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	pb "github.com/rpg-tools/toolbox-services/proto"
+	"google.golang.org/grpc"
+)
+
+type grpcServer struct {
+	services *tableServices
+}
+
+func RegisterGrpc(server *grpc.Server) {
+	pb.RegisterVirtualTableServiceServer(server, &grpcServer{services: &tableServices{}})
+}
+
+func (s *grpcServer) CreateTable(ctx context.Context, req *pb.CreateTableRequest) (*pb.Event, error) {
+	evt, err := s.services.CreateTable(CreateTableCmd{Name: req.Name, GameSystem: req.GameSystem}, ctx)
+	if err != nil {
+		return nil, lib.ToGrpcError(err)
+	}
+	return toPbEvent(evt)
+}
+
+func (s *grpcServer) GetTable(ctx context.Context, req *pb.GetTableRequest) (*pb.Table, error) {
+	table, err := s.services.ById(req.Id, ctx)
+	if err != nil {
+		return nil, lib.ToGrpcError(err)
+	}
+	if table == nil {
+		return nil, lib.ToGrpcError(lib.HttpNotFound(nil))
+	}
+	return toPbTable(table)
+}
+
+func (s *grpcServer) SearchTables(ctx context.Context, req *pb.SearchTablesRequest) (*pb.SearchTablesResponse, error) {
+	query := SearchTablesQuery{Name: req.Name, Archived: req.Archived, Deleted: req.Deleted, Templates: req.Templates}
+	tables, err := s.services.Search(query, ctx)
+	if err != nil {
+		return nil, lib.ToGrpcError(err)
+	}
+	res := &pb.SearchTablesResponse{Tables: make([]*pb.Table, len(tables))}
+	for idx, table := range tables {
+		if res.Tables[idx], err = toPbTable(table); err != nil {
+			return nil, lib.ToGrpcError(err)
+		}
+	}
+	return res, nil
+}
+
+func (s *grpcServer) Subscribe(req *pb.SubscribeRequest, stream pb.VirtualTableService_SubscribeServer) error {
+	ctx := stream.Context()
+	user := app_context.GetAuthUser(ctx)
+	// Unknown categories, like wildcards, would widen the subjects.
+	for _, category := range req.Categories {
+		if !eventCategories[EventCategory(category)] {
+			return lib.ToGrpcError(lib.HttpBadRequest(fmt.Errorf("%s is not a valid category", category)))
+		}
+	}
+	table, err := s.services.loadTable(req.TableId, ctx)
+	if err != nil {
+		return lib.ToGrpcError(err)
+	}
+	if !table.IsMember(user) {
+		return lib.ToGrpcError(lib.HttpForbidden(fmt.Errorf("only the members of the table can subscribe")))
+	}
+	if table.ArchivedAt != nil {
+		return lib.ToGrpcError(lib.HttpConflict(fmt.Errorf("table %s is archived", req.TableId)))
+	}
+
+	subjects := []string{TableSubject(req.TableId)}
+	var categories map[EventCategory]bool
+	if len(req.Categories) > 0 {
+		subjects = make([]string, 0, len(req.Categories))
+		categories = make(map[EventCategory]bool, len(req.Categories))
+		for _, category := range req.Categories {
+			categories[EventCategory(category)] = true
+			subjects = append(subjects, CategorySubject(req.TableId, EventCategory(category)))
+		}
+		// Followed whatever the categories, to end the stream of a kicked user or of an archived table.
+		for _, kind := range closingEventKinds {
+			if !categories[CategoryOf(kind)] {
+				subjects = append(subjects, KindSubject(req.TableId, kind))
+			}
+		}
+	}
+	sub, err := s.services.subscribe(table, subjects, ctx)
+	if err != nil {
+		return lib.ToGrpcError(err)
+	}
+	defer func() {
+		sub.Close()
+		s.services.sendEvent(ctx, table.Id, &PlayerDisconnected{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user})
+	}()
+
+	// TODO send via service.
+	s.services.sendEvent(ctx, table.Id, &PlayerConnected{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message := <-sub.Messages:
+			data, visible := sub.Visible(message)
+			if !visible {
+				continue
+			}
+			closes := sub.Closes(message)
+			// The closing events of the other categories are only sent when they end the stream.
+			if categories != nil && !categories[SubjectCategory(message.Subject)] && !closes {
+				continue
+			}
+			evt, err := ReadEventJson(data)
+			if err != nil {
+				return lib.ToGrpcError(err)
+			}
+			res, err := toPbEvent(evt)
+			if err != nil {
+				return lib.ToGrpcError(err)
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+			if closes {
+				return nil
+			}
+		}
+	}
+}
+
+// Conversions
+
+func toPbHandout(h *Handout) *pb.Handout {
+	return &pb.Handout{Id: h.Id, Name: h.Name, Content: h.Content, SharedWith: h.SharedWith}
+}
+
+func toPbTable(table *TableWithEvents) (*pb.Table, error) {
+	res := &pb.Table{
+		Id:          table.Id.Hex(),
+		Name:        table.Name,
+		Master:      table.Master,
+		Players:     table.Players,
+		Characters:  make([]*pb.Character, len(table.Characters)),
+		Discussions: make([]*pb.Discussion, len(table.Discussions)),
+		Handouts:    make([]*pb.Handout, len(table.Handouts)),
+		Events:      make([]*pb.Event, len(table.Events)),
+		Users:       make(map[string]*pb.UserProfile, len(table.Users)),
+	}
+	for id, u := range table.Users {
+		res.Users[id] = &pb.UserProfile{Id: u.Id.Hex(), Name: u.Name, Picture: u.Picture}
+	}
+	for idx := range table.Characters {
+		character, err := toPbCharacter(&table.Characters[idx])
+		if err != nil {
+			return nil, err
+		}
+		res.Characters[idx] = character
+	}
+	for idx, d := range table.Discussions {
+		discussion := &pb.Discussion{Id: d.Id, Name: d.Name, Persistent: d.Persistent, Between: d.Between, Messages: make([]*pb.Message, len(d.Messages))}
+		for mIdx, m := range d.Messages {
+			at, err := ptypes.TimestampProto(m.At)
+			if err != nil {
+				return nil, err
+			}
+			discussion.Messages[mIdx] = &pb.Message{Content: m.Content, By: m.By, At: at}
+		}
+		res.Discussions[idx] = discussion
+	}
+	for idx := range table.Handouts {
+		res.Handouts[idx] = toPbHandout(&table.Handouts[idx])
+	}
+	for idx, e := range table.Events {
+		evt, err := toPbEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		res.Events[idx] = evt
+	}
+	return res, nil
+}
+
+func toPbCharacter(c *Character) (*pb.Character, error) {
+	sheet, err := json.Marshal(c.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.Character{Id: c.Id, Table: c.Table, Player: c.Player, Name: c.Name, Picture: c.Picture, Hidden: c.Hidden, Retired: c.Retired, Sheet: sheet, Derived: c.Derived, Macros: make([]*pb.Macro, len(c.Macros))}
+	for idx, m := range c.Macros {
+		res.Macros[idx] = &pb.Macro{Name: m.Name, Expression: m.Expression}
+	}
+	return res, nil
+}
+
+func toPbSettings(settings *TableSettings) *pb.TableSettings {
+	res := &pb.TableSettings{Permissions: make(map[string]*pb.Roles, len(settings.Permissions)), Discoverable: settings.Discoverable, Template: settings.Template, GameSystem: settings.GameSystem}
+	for kind, roles := range settings.Permissions {
+		r := &pb.Roles{Roles: make([]string, len(roles))}
+		for idx, role := range roles {
+			r.Roles[idx] = string(role)
+		}
+		res.Permissions[string(kind)] = r
+	}
+	return res
+}
+
+func toPbLobby(lobby *Lobby) (*pb.Lobby, error) {
+	res := &pb.Lobby{Description: lobby.Description, GameSystem: lobby.GameSystem, Language: lobby.Language, Tags: lobby.Tags, PlayerCap: int32(lobby.PlayerCap), Schedule: &pb.Schedule{Description: lobby.Schedule.Description}}
+	if lobby.Schedule.NextSession != nil {
+		next, err := ptypes.TimestampProto(*lobby.Schedule.NextSession)
+		if err != nil {
+			return nil, err
+		}
+		res.Schedule.NextSession = next
+	}
+	return res, nil
+}
+
+func toPbCombatant(c *Combatant) (*pb.Combatant, error) {
+	res := &pb.Combatant{Id: c.Id, Character: c.Character, Player: c.Player, Name: c.Name, Hidden: c.Hidden, Initiative: int32(c.Initiative), Modifier: int32(c.Modifier), TieBreak: int32(c.TieBreak), Status: string(c.Status), Trigger: c.Trigger}
+	if c.Roll != nil {
+		roll, err := json.Marshal(c.Roll)
+		if err != nil {
+			return nil, err
+		}
+		res.Roll = roll
+	}
+	return res, nil
+}
+
+func toPbEncounter(e *Encounter) (*pb.Encounter, error) {
+	startedAt, err := ptypes.TimestampProto(e.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.Encounter{Id: e.Id, Name: e.Name, InitiativeDice: e.InitiativeDice, Combatants: make([]*pb.Combatant, len(e.Combatants)), Round: int32(e.Round), Current: e.Current, StartedAt: startedAt}
+	for idx := range e.Combatants {
+		if res.Combatants[idx], err = toPbCombatant(&e.Combatants[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func toPbEvent(evt Event) (*pb.Event, error) {
+	at, err := ptypes.TimestampProto(evt.GetAt())
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.Event{Id: evt.GetId(), TableId: evt.GetTableId(), By: evt.GetBy(), At: at, Kind: string(evt.Kind())}
+	switch e := evt.(type) {
+	case *TableCreated:
+		res.Payload = &pb.Event_TableCreated{TableCreated: &pb.TableCreated{Name: e.Name, ClonedFrom: e.ClonedFrom}}
+	case *PlayerJoint:
+		res.Payload = &pb.Event_PlayerJoint{PlayerJoint: &pb.PlayerJoint{Player: e.Player, Role: string(e.Role)}}
+	case *PlayerConnected:
+		res.Payload = &pb.Event_PlayerConnected{PlayerConnected: &pb.PlayerConnected{Player: e.Player}}
+	case *PlayerDisconnected:
+		res.Payload = &pb.Event_PlayerDisconnected{PlayerDisconnected: &pb.PlayerDisconnected{Player: e.Player}}
+	case *PlayerWritingMessage:
+		res.Payload = &pb.Event_PlayerWritingMessage{PlayerWritingMessage: &pb.PlayerWritingMessage{Player: e.Player, Discussion: e.Discussion}}
+	case *PlayerStopWritingMessage:
+		res.Payload = &pb.Event_PlayerStopWritingMessage{PlayerStopWritingMessage: &pb.PlayerStopWritingMessage{Player: e.Player, Discussion: e.Discussion}}
+	case *PlayerSentMessage:
+		res.Payload = &pb.Event_PlayerSentMessage{PlayerSentMessage: &pb.PlayerSentMessage{Player: e.Player, Discussion: e.Discussion, Message: e.Message}}
+	case *BotInvited:
+		commands := make([]string, len(e.Commands))
+		for idx, c := range e.Commands {
+			commands[idx] = string(c)
+		}
+		res.Payload = &pb.Event_BotInvited{BotInvited: &pb.BotInvited{Bot: e.Bot, Commands: commands}}
+	case *BotRemoved:
+		res.Payload = &pb.Event_BotRemoved{BotRemoved: &pb.BotRemoved{Bot: e.Bot}}
+	case *SettingsUpdated:
+		res.Payload = &pb.Event_SettingsUpdated{SettingsUpdated: &pb.SettingsUpdated{Settings: toPbSettings(&e.Settings)}}
+	case *InviteCreated:
+		expiresAt, err := ptypes.TimestampProto(e.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_InviteCreated{InviteCreated: &pb.InviteCreated{Invite: e.Invite, Token: e.Token, Role: string(e.Role), ExpiresAt: expiresAt, MaxUses: int32(e.MaxUses)}}
+	case *InviteRevoked:
+		res.Payload = &pb.Event_InviteRevoked{InviteRevoked: &pb.InviteRevoked{Invite: e.Invite}}
+	case *LobbyUpdated:
+		lobby, err := toPbLobby(&e.Lobby)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_LobbyUpdated{LobbyUpdated: &pb.LobbyUpdated{Lobby: lobby}}
+	case *JoinRequested:
+		res.Payload = &pb.Event_JoinRequested{JoinRequested: &pb.JoinRequested{Request: e.Request, Player: e.Player, Message: e.Message}}
+	case *JoinRequestApproved:
+		res.Payload = &pb.Event_JoinRequestApproved{JoinRequestApproved: &pb.JoinRequestApproved{Request: e.Request, Player: e.Player}}
+	case *JoinRequestRejected:
+		res.Payload = &pb.Event_JoinRequestRejected{JoinRequestRejected: &pb.JoinRequestRejected{Request: e.Request, Player: e.Player, Reason: e.Reason}}
+	case *CharacterCreated:
+		character, err := toPbCharacter(&e.Character)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_CharacterCreated{CharacterCreated: &pb.CharacterCreated{Character: character}}
+	case *CharacterUpdated:
+		character, err := toPbCharacter(&e.Character)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_CharacterUpdated{CharacterUpdated: &pb.CharacterUpdated{Character: character}}
+	case *CharacterAssigned:
+		res.Payload = &pb.Event_CharacterAssigned{CharacterAssigned: &pb.CharacterAssigned{Character: e.Character, Player: e.Player}}
+	case *CharacterRetired:
+		res.Payload = &pb.Event_CharacterRetired{CharacterRetired: &pb.CharacterRetired{Character: e.Character}}
+	case *CharacterDeleted:
+		res.Payload = &pb.Event_CharacterDeleted{CharacterDeleted: &pb.CharacterDeleted{Character: e.Character}}
+	case *MacroSaved:
+		res.Payload = &pb.Event_MacroSaved{MacroSaved: &pb.MacroSaved{Character: e.Character, Macro: &pb.Macro{Name: e.Macro.Name, Expression: e.Macro.Expression}}}
+	case *MacroDeleted:
+		res.Payload = &pb.Event_MacroDeleted{MacroDeleted: &pb.MacroDeleted{Character: e.Character, Name: e.Name}}
+	case *DiscussionUpdated:
+		res.Payload = &pb.Event_DiscussionUpdated{DiscussionUpdated: &pb.DiscussionUpdated{Discussion: e.Discussion, Template: e.Template}}
+	case *TableArchived:
+		res.Payload = &pb.Event_TableArchived{TableArchived: &pb.TableArchived{}}
+	case *TableDeleted:
+		res.Payload = &pb.Event_TableDeleted{TableDeleted: &pb.TableDeleted{}}
+	case *TableRestored:
+		res.Payload = &pb.Event_TableRestored{TableRestored: &pb.TableRestored{}}
+	case *OwnershipTransferred:
+		res.Payload = &pb.Event_OwnershipTransferred{OwnershipTransferred: &pb.OwnershipTransferred{From: e.From, To: e.To}}
+	case *CoMasterAdded:
+		res.Payload = &pb.Event_CoMasterAdded{CoMasterAdded: &pb.CoMasterAdded{Player: e.Player}}
+	case *CoMasterRemoved:
+		res.Payload = &pb.Event_CoMasterRemoved{CoMasterRemoved: &pb.CoMasterRemoved{Player: e.Player}}
+	case *PlayerKicked:
+		res.Payload = &pb.Event_PlayerKicked{PlayerKicked: &pb.PlayerKicked{Player: e.Player}}
+	case *PlayerBanned:
+		res.Payload = &pb.Event_PlayerBanned{PlayerBanned: &pb.PlayerBanned{Player: e.Player, Reason: e.Reason}}
+	case *PlayerUnbanned:
+		res.Payload = &pb.Event_PlayerUnbanned{PlayerUnbanned: &pb.PlayerUnbanned{Player: e.Player}}
+	case *PlayerMuted:
+		until, err := ptypes.TimestampProto(e.Until)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_PlayerMuted{PlayerMuted: &pb.PlayerMuted{Player: e.Player, Discussion: e.Discussion, Until: until}}
+	case *DiceRolled:
+		roll, err := json.Marshal(e.Roll)
+		if err != nil {
+			return nil, err
+		}
+		rolled := &pb.DiceRolled{Player: e.Player, Discussion: e.Discussion, Label: e.Label, Roll: roll, Visibility: string(e.Visibility), Character: e.Character, Macro: e.Macro}
+		if e.Fair != nil {
+			rolled.Fair = &pb.FairRoll{Session: e.Fair.Session, ServerSeedHash: e.Fair.ServerSeedHash, ClientSeed: e.Fair.ClientSeed, Nonce: int32(e.Fair.Nonce)}
+		}
+		res.Payload = &pb.Event_DiceRolled{DiceRolled: rolled}
+	case *RollRevealed:
+		roll, err := json.Marshal(e.Roll)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_RollRevealed{RollRevealed: &pb.RollRevealed{RollId: e.RollId, Player: e.Player, Discussion: e.Discussion, Label: e.Label, Roll: roll}}
+	case *DiceSessionStarted:
+		res.Payload = &pb.Event_DiceSessionStarted{DiceSessionStarted: &pb.DiceSessionStarted{Session: e.Session, ServerSeedHash: e.ServerSeedHash}}
+	case *ClientSeedAdded:
+		res.Payload = &pb.Event_ClientSeedAdded{ClientSeedAdded: &pb.ClientSeedAdded{Session: e.Session, Player: e.Player, Seed: e.Seed}}
+	case *DiceSessionEnded:
+		res.Payload = &pb.Event_DiceSessionEnded{DiceSessionEnded: &pb.DiceSessionEnded{Session: e.Session, ServerSeed: e.ServerSeed, Rolls: int32(e.Rolls)}}
+	case *EncounterStarted:
+		encounter, err := toPbEncounter(&e.Encounter)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_EncounterStarted{EncounterStarted: &pb.EncounterStarted{Encounter: encounter}}
+	case *CombatantAdded:
+		combatant, err := toPbCombatant(&e.Combatant)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_CombatantAdded{CombatantAdded: &pb.CombatantAdded{Combatant: combatant}}
+	case *CombatantRemoved:
+		res.Payload = &pb.Event_CombatantRemoved{CombatantRemoved: &pb.CombatantRemoved{Combatant: e.Combatant, HiddenCombatant: e.HiddenCombatant, Round: int32(e.Round), Current: e.Current, HiddenCurrent: e.HiddenCurrent}}
+	case *TurnChanged:
+		res.Payload = &pb.Event_TurnChanged{TurnChanged: &pb.TurnChanged{Action: string(e.Action), Combatant: e.Combatant, HiddenCombatant: e.HiddenCombatant, Trigger: e.Trigger, Initiative: int32(e.Initiative), Round: int32(e.Round), Current: e.Current, HiddenCurrent: e.HiddenCurrent}}
+	case *EncounterEnded:
+		res.Payload = &pb.Event_EncounterEnded{EncounterEnded: &pb.EncounterEnded{Encounter: e.Encounter, Rounds: int32(e.Rounds)}}
+	case *HandoutCreated:
+		res.Payload = &pb.Event_HandoutCreated{HandoutCreated: &pb.HandoutCreated{Handout: toPbHandout(&e.Handout)}}
+	case *HandoutShared:
+		res.Payload = &pb.Event_HandoutShared{HandoutShared: &pb.HandoutShared{Handout: toPbHandout(&e.Handout)}}
+	case *HandoutDeleted:
+		res.Payload = &pb.Event_HandoutDeleted{HandoutDeleted: &pb.HandoutDeleted{Handout: e.Handout}}
+	default:
+		// Kinds without dedicated message are sent as json.
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &pb.Event_Json{Json: data}
+	}
+	return res, nil
+}
@@ -0,0 +1,90 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// visibleHandouts removes the handouts the user cannot see.
+func (t *Table) visibleHandouts(user string) {
+	handouts := make([]Handout, 0, len(t.Handouts))
+	for _, h := range t.Handouts {
+		if t.Includes(h.Audience(), user) {
+			handouts = append(handouts, h)
+		}
+	}
+	t.Handouts = handouts
+}
+
+// sharedWith checks that the handout is shared with everyone or with members of the table.
+func sharedWith(table *Table, users []string) ([]string, error) {
+	if users == nil {
+		return []string{}, nil
+	}
+	for _, u := range users {
+		if u != "*" && table.RoleOf(u) == "" {
+			return nil, lib.HttpBadRequest(fmt.Errorf("%s is not a member of the table", u))
+		}
+	}
+	return users, nil
+}
+
+// Commands.
+
+func (s *tableServices) CreateHandout(table *Table, cmd *CreateHandoutCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if cmd.Name == "" {
+		return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+	}
+	users, err := sharedWith(table, cmd.SharedWith)
+	if err != nil {
+		return nil, err
+	}
+	handout := Handout{Id: uuid.New().String(), Name: cmd.Name, Content: cmd.Content, SharedWith: users}
+
+	evt := &HandoutCreated{EventBase: NewEventBase(table.Id, handout.Audience(), by), Handout: handout}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$push": bson.M{"handouts": handout}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) ShareHandout(table *Table, cmd *ShareHandoutCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	handout := table.Handout(cmd.Handout)
+	if handout == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("handout %s not found", cmd.Handout))
+	}
+	users, err := sharedWith(table, cmd.SharedWith)
+	if err != nil {
+		return nil, err
+	}
+	updated := *handout
+	updated.SharedWith = users
+
+	// Sent to the users who saw the handout, and to the ones who see it now.
+	evt := &HandoutShared{EventBase: NewEventBase(table.Id, append(handout.Audience(), users...), by), Handout: updated}
+	update := bson.M{"$set": bson.M{"handouts.$[h].sharedWith": users}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"h.id": handout.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) DeleteHandout(table *Table, cmd *DeleteHandoutCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	handout := table.Handout(cmd.Handout)
+	if handout == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("handout %s not found", cmd.Handout))
+	}
+
+	evt := &HandoutDeleted{EventBase: NewEventBase(table.Id, handout.Audience(), by), Handout: handout.Id}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$pull": bson.M{"handouts": bson.M{"id": handout.Id}}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,186 @@
+package virtual_table
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const (
+	invitesCollectionName = "tables_invites"
+	defaultInviteTtl      = 7 * 24 * time.Hour
+)
+
+// freeSeatFilter matches the tables which are not full, the opposite of IsFull.
+var freeSeatFilter = bson.M{"$or": bson.A{
+	bson.M{"lobby.playerCap": bson.M{"$not": bson.M{"$gt": 0}}},
+	bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$size": "$players"}, "$lobby.playerCap"}}},
+}}
+
+func newInviteToken() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Read part
+
+// Invites lists the invites of the table, to the users allowed to create them.
+func (s *tableServices) Invites(tableId string, ctx context.Context) ([]*Invite, error) {
+	db := app_context.GetMongodb(ctx)
+	table, err := s.loadTable(tableId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(table, CreateInviteKind, ctx); err != nil {
+		return nil, err
+	}
+	cursor, err := db.Collection(invitesCollectionName).Find(ctx, bson.M{"tableId": table.Id})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Invite, 0)
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Commands.
+
+func (s *tableServices) CreateInvite(table *Table, cmd *CreateInviteCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	role := cmd.Role
+	if role == "" {
+		role = PlayerRole
+	}
+	if role != PlayerRole && role != SpectatorRole {
+		return nil, lib.HttpBadRequest(fmt.Errorf("invites can give only the roles %s and %s", PlayerRole, SpectatorRole))
+	}
+	if cmd.MaxUses < 0 {
+		return nil, lib.HttpBadRequest(fmt.Errorf("maxUses must be positive"))
+	}
+	now := time.Now()
+	expiresAt := cmd.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultInviteTtl)
+	}
+	if !expiresAt.After(now) {
+		return nil, lib.HttpBadRequest(fmt.Errorf("expiresAt must be in the future"))
+	}
+	token, err := newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &Invite{Id: primitive.NewObjectID(), Token: token, TableId: table.Id, Role: role, By: by, CreatedAt: now, ExpiresAt: expiresAt, MaxUses: cmd.MaxUses}
+	if _, err := db.Collection(invitesCollectionName).InsertOne(ctx, invite); err != nil {
+		return nil, err
+	}
+	evt := &InviteCreated{EventBase: NewEventBase(table.Id, []string{MastersAudience}, by), Invite: invite.Id.Hex(), Token: token, Role: role, ExpiresAt: expiresAt, MaxUses: cmd.MaxUses}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) RevokeInvite(table *Table, cmd *RevokeInviteCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	id, err := primitive.ObjectIDFromHex(cmd.Invite)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	res, err := db.Collection(invitesCollectionName).UpdateOne(ctx,
+		bson.M{"_id": id, "tableId": table.Id, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": time.Now()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, lib.HttpNotFound(fmt.Errorf("invite %s not found", cmd.Invite))
+	}
+	evt := &InviteRevoked{EventBase: NewEventBase(table.Id, []string{MastersAudience}, by), Invite: cmd.Invite}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// RedeemInvite adds the authenticated user to the table of the invite, with the role of the invite.
+func (s *tableServices) RedeemInvite(token string, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	user := app_context.GetAuthUser(ctx)
+	now := time.Now()
+	usable := bson.M{
+		"token":     token,
+		"revokedAt": bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+		"$or": bson.A{
+			bson.M{"maxUses": 0},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$uses", "$maxUses"}}},
+		},
+	}
+	invite := &Invite{}
+	err := db.Collection(invitesCollectionName).FindOne(ctx, usable).Decode(invite)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("invalid or expired invite"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	table, err := s.loadTable(invite.TableId.Hex(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(nil, JoinTableKind, ctx); err != nil {
+		return nil, err
+	}
+	if table.ArchivedAt != nil {
+		return nil, lib.HttpConflict(fmt.Errorf("table %s is archived", table.Id.Hex()))
+	}
+	if table.IsBanned(user) {
+		return nil, lib.HttpForbidden(fmt.Errorf("banned from the table"))
+	}
+	if table.IsMember(user) {
+		return nil, lib.HttpConflict(fmt.Errorf("already a member of the table"))
+	}
+	full := lib.HttpConflict(fmt.Errorf("table %s is full", table.Id.Hex()))
+	// The spectators do not take a seat.
+	if invite.Role != SpectatorRole && table.IsFull() {
+		return nil, full
+	}
+
+	// Consume the invite with the membership, unless it was used up in the meantime.
+	consume := func(sc context.Context) error {
+		res, err := db.Collection(invitesCollectionName).UpdateOne(sc, usable, bson.M{"$inc": bson.M{"uses": 1}})
+		if err != nil {
+			return err
+		}
+		if res.ModifiedCount == 0 {
+			return lib.HttpNotFound(fmt.Errorf("invalid or expired invite"))
+		}
+		return nil
+	}
+	join := tableUpdate{update: bson.M{"$addToSet": bson.M{"spectators": user}}}
+	if invite.Role != SpectatorRole {
+		// Checked again when updated, the table may have been filled since it was read.
+		join = tableUpdate{update: bson.M{"$addToSet": bson.M{"players": user}}, filter: freeSeatFilter, mismatch: full}
+	}
+	evt := &PlayerJoint{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user, Role: invite.Role}
+	if err := s.commitWith(ctx, table.Id, evt, consume, join); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
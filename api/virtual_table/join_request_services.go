@@ -0,0 +1,158 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const (
+	joinRequestsCollectionName = "tables_join_requests"
+)
+
+// pendingJoinRequest reads a pending request of the table.
+func (*tableServices) pendingJoinRequest(table *Table, id string, ctx context.Context) (*JoinRequest, error) {
+	db := app_context.GetMongodb(ctx)
+	bsonId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	res := &JoinRequest{}
+	err = db.Collection(joinRequestsCollectionName).FindOne(ctx, bson.M{"_id": bsonId, "tableId": table.Id, "status": PendingJoinRequest}).Decode(res)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("join request %s not found", id))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// decideJoinRequest closes the request, unless it was decided in the meantime.
+func (*tableServices) decideJoinRequest(request *JoinRequest, status JoinRequestStatus, reason string, ctx context.Context) error {
+	db := app_context.GetMongodb(ctx)
+	now := time.Now()
+	res, err := db.Collection(joinRequestsCollectionName).UpdateOne(ctx,
+		bson.M{"_id": request.Id, "status": PendingJoinRequest},
+		bson.M{"$set": bson.M{"status": status, "decidedBy": app_context.GetAuthUser(ctx), "decidedAt": now, "reason": reason}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return lib.HttpConflict(fmt.Errorf("join request %s already decided", request.Id.Hex()))
+	}
+	return nil
+}
+
+// Read part
+
+// JoinRequests lists the pending requests of the table, to the users allowed to approve them.
+func (s *tableServices) JoinRequests(tableId string, ctx context.Context) ([]*JoinRequest, error) {
+	db := app_context.GetMongodb(ctx)
+	table, err := s.loadTable(tableId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(table, ApproveJoinRequestKind, ctx); err != nil {
+		return nil, err
+	}
+	cursor, err := db.Collection(joinRequestsCollectionName).Find(ctx, bson.M{"tableId": table.Id, "status": PendingJoinRequest})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*JoinRequest, 0)
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Commands.
+
+// RequestJoin submits a request to join a discoverable table, the authenticated user is not a member yet.
+func (s *tableServices) RequestJoin(tableId string, cmd *RequestJoinCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	user := app_context.GetAuthUser(ctx)
+	table, err := s.loadTable(tableId, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(nil, cmd.Kind(), ctx); err != nil {
+		return nil, err
+	}
+	if table.ArchivedAt != nil {
+		return nil, lib.HttpConflict(fmt.Errorf("table %s is archived", tableId))
+	}
+	if table.IsBanned(user) {
+		return nil, lib.HttpForbidden(fmt.Errorf("banned from the table"))
+	}
+	if !table.Settings.Discoverable {
+		return nil, lib.HttpForbidden(fmt.Errorf("table %s does not accept join requests", tableId))
+	}
+	if table.IsMember(user) {
+		return nil, lib.HttpConflict(fmt.Errorf("already a member of the table"))
+	}
+	count, err := db.Collection(joinRequestsCollectionName).CountDocuments(ctx, bson.M{"tableId": table.Id, "user": user, "status": PendingJoinRequest})
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, lib.HttpConflict(fmt.Errorf("a join request is already pending"))
+	}
+
+	request := &JoinRequest{Id: primitive.NewObjectID(), TableId: table.Id, User: user, Message: cmd.Message, CreatedAt: time.Now(), Status: PendingJoinRequest}
+	if _, err := db.Collection(joinRequestsCollectionName).InsertOne(ctx, request); err != nil {
+		return nil, err
+	}
+	evt := &JoinRequested{EventBase: NewEventBase(table.Id, []string{MastersAudience, user}, user), Request: request.Id.Hex(), Player: user, Message: cmd.Message}
+	if err := s.commit(ctx, table.Id, evt, nil); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) ApproveJoinRequest(table *Table, cmd *ApproveJoinRequestCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	request, err := s.pendingJoinRequest(table, cmd.Request, ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Checked again, the user may have been banned or the table filled since the request.
+	if table.IsBanned(request.User) {
+		return nil, lib.HttpForbidden(fmt.Errorf("user %s is banned from the table", request.User))
+	}
+	if table.IsFull() {
+		return nil, lib.HttpConflict(fmt.Errorf("table %s is full", table.Id.Hex()))
+	}
+	evt := &JoinRequestApproved{EventBase: NewEventBase(table.Id, []string{"*"}, by), Request: cmd.Request, Player: request.User}
+	// The request stays pending when the user is not added.
+	decide := func(sc context.Context) error {
+		return s.decideJoinRequest(request, ApprovedJoinRequest, "", sc)
+	}
+	if err := s.commitWith(ctx, table.Id, evt, decide, tableUpdate{update: bson.M{"$addToSet": bson.M{"players": request.User}}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) RejectJoinRequest(table *Table, cmd *RejectJoinRequestCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	request, err := s.pendingJoinRequest(table, cmd.Request, ctx)
+	if err != nil {
+		return nil, err
+	}
+	evt := &JoinRequestRejected{EventBase: NewEventBase(table.Id, []string{MastersAudience, request.User}, by), Request: cmd.Request, Player: request.User, Reason: cmd.Reason}
+	decide := func(sc context.Context) error {
+		return s.decideJoinRequest(request, RejectedJoinRequest, cmd.Reason, sc)
+	}
+	if err := s.commitWith(ctx, table.Id, evt, decide); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,108 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	// DefaultTrashRetention is the delay after which the deleted tables are purged.
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	purgeInterval         = time.Hour
+)
+
+// Commands.
+
+// ArchiveTable makes the table read-only and hides it from the default listings.
+func (s *tableServices) ArchiveTable(table *Table, cmd *ArchiveTableCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	evt := &TableArchived{EventBase: NewEventBase(table.Id, []string{"*"}, by)}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"archivedAt": evt.GetAt()}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// DeleteTable moves the table to the trash, it is purged after the retention period.
+func (s *tableServices) DeleteTable(table *Table, cmd *DeleteTableCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	evt := &TableDeleted{EventBase: NewEventBase(table.Id, []string{"*"}, by)}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"deletedAt": evt.GetAt()}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// RestoreTable gets the table back from the archive or the trash.
+func (s *tableServices) RestoreTable(table *Table, cmd *RestoreTableCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if table.ArchivedAt == nil && table.DeletedAt == nil {
+		return nil, lib.HttpConflict(fmt.Errorf("table %s is neither archived nor deleted", table.Id.Hex()))
+	}
+	evt := &TableRestored{EventBase: NewEventBase(table.Id, []string{"*"}, by)}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$unset": bson.M{"archivedAt": "", "deletedAt": ""}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// Purge.
+
+// purge removes the tables deleted before the retention period, with their journal, invites, join requests
+// and dice sessions.
+func (s *tableServices) purge(retention time.Duration, ctx context.Context) (int, error) {
+	db := app_context.GetMongodb(ctx)
+	cutoff := time.Now().Add(-retention)
+	ids, err := db.Collection(collectionName).Distinct(ctx, "_id", bson.M{"deletedAt": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, id := range ids {
+		if err := s.withMongoTransaction(ctx, db, func(sc context.Context) error {
+			// Filtered again on the date, the table may have been restored since it was listed.
+			res, err := db.Collection(collectionName).DeleteOne(sc, bson.M{"_id": id, "deletedAt": bson.M{"$lt": cutoff}})
+			if err != nil || res.DeletedCount == 0 {
+				return err
+			}
+			for _, collection := range []string{journalCollectionName, invitesCollectionName, joinRequestsCollectionName, diceSessionsCollectionName} {
+				if _, err := db.Collection(collection).DeleteMany(sc, bson.M{"tableId": id}); err != nil {
+					return err
+				}
+			}
+			purged++
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return purged, nil
+}
+
+// StartPurge purges the deleted tables every hour in background, until the context is done.
+// The context is enriched like the one of a request.
+func StartPurge(retention time.Duration, ctx context.Context) {
+	services := &tableServices{}
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+		for {
+			count, err := services.purge(retention, ctx)
+			if err != nil {
+				log.Errorf("unable to purge the deleted tables: %v", err)
+			} else if count > 0 {
+				log.Infof("%d deleted tables purged", count)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,111 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/user"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultLobbyLimit = 20
+	maxLobbyLimit     = 100
+)
+
+// Read part
+
+// Lobby searches the discoverable tables, only their public projection is returned.
+func (*tableServices) Lobby(query LobbyQuery, ctx context.Context) ([]*PublicTable, error) {
+	db := app_context.GetMongodb(ctx)
+	filters := bson.A{bson.M{"settings.discoverable": true}, activeFilter}
+	if query.Text != "" {
+		filters = append(filters, bson.M{"$text": bson.M{"$search": query.Text}})
+	}
+	if query.GameSystem != "" {
+		filters = append(filters, bson.M{"lobby.gameSystem": query.GameSystem})
+	}
+	if query.Language != "" {
+		filters = append(filters, bson.M{"lobby.language": query.Language})
+	}
+	if len(query.Tags) > 0 {
+		filters = append(filters, bson.M{"lobby.tags": bson.M{"$all": query.Tags}})
+	}
+	if query.FreeSeats {
+		filters = append(filters, bson.M{"$or": bson.A{
+			bson.M{"lobby.playerCap": bson.M{"$in": bson.A{0, nil}}},
+			bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$players", bson.A{}}}}, "$lobby.playerCap"}}},
+		}})
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLobbyLimit
+	}
+	if limit > maxLobbyLimit {
+		limit = maxLobbyLimit
+	}
+
+	project := bson.M{
+		"name":         1,
+		"master":       1,
+		"lobby":        1,
+		"playersCount": bson.M{"$size": bson.M{"$ifNull": bson.A{"$players", bson.A{}}}},
+	}
+	sort := bson.D{{Key: "lobby.schedule.nextSession", Value: 1}, {Key: "_id", Value: -1}}
+	if query.Text != "" {
+		project["score"] = bson.M{"$meta": "textScore"}
+		sort = bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}
+	}
+	opts := options.Find().SetProjection(project).SetSort(sort).SetLimit(int64(limit)).SetSkip(int64(query.Offset))
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"$and": filters}, opts)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*PublicTable, 0)
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, err
+	}
+
+	masters := make([]string, len(res))
+	for idx, t := range res {
+		masters[idx] = t.Master
+	}
+	profiles, err := user.Profiles(masters, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range res {
+		t.FreeSeats = -1
+		if t.Lobby.PlayerCap > 0 {
+			t.FreeSeats = t.Lobby.PlayerCap - t.PlayersCount
+			if t.FreeSeats < 0 {
+				t.FreeSeats = 0
+			}
+		}
+		t.Users = make(map[string]user.Profile)
+		if p, ok := profiles[t.Master]; ok {
+			t.Users[t.Master] = p
+		}
+	}
+	return res, nil
+}
+
+// Commands.
+
+func (s *tableServices) UpdateLobby(table *Table, cmd *UpdateLobbyCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	lobby := cmd.Lobby
+	if lobby.PlayerCap < 0 {
+		return nil, lib.HttpBadRequest(fmt.Errorf("playerCap must be positive"))
+	}
+	if lobby.Tags == nil {
+		lobby.Tags = []string{}
+	}
+	evt := &LobbyUpdated{EventBase: NewEventBase(table.Id, []string{"*"}, by), Lobby: lobby}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"lobby": lobby}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
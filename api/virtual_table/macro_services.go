@@ -0,0 +1,180 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/api/game_system"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const maxMacrosByCharacter = 50
+
+var (
+	// Names are typed in the chat, after `/macro`.
+	macroNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+	// Parameters are derived fields or dotted paths of the sheet, like `@proficiencyBonus` or `@abilities.str`.
+	macroParameterPattern = regexp.MustCompile(`@([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)`)
+)
+
+// macroCommand reads the `/macro name # label` chat command, ok is false for the other messages.
+func macroCommand(message string) (cmd *RunMacroCmd, ok bool) {
+	message = strings.TrimSpace(message)
+	if message != "/macro" && !strings.HasPrefix(message, "/macro ") {
+		return nil, false
+	}
+	cmd = &RunMacroCmd{Name: strings.TrimPrefix(message, "/macro")}
+	if i := strings.Index(cmd.Name, "#"); i >= 0 {
+		cmd.Label = strings.TrimSpace(cmd.Name[i+1:])
+		cmd.Name = cmd.Name[:i]
+	}
+	cmd.Name = strings.TrimSpace(cmd.Name)
+	return cmd, true
+}
+
+// expandMacro replaces the parameters of the macro by the values of the sheet of the character.
+func (c *Character) expandMacro(expression string) (string, error) {
+	var err error
+	res := macroParameterPattern.ReplaceAllStringFunc(expression, func(param string) string {
+		if err != nil {
+			return param
+		}
+		path := strings.TrimPrefix(param, "@")
+		v, e := game_system.Field(c.Sheet, c.Derived, path)
+		if e != nil {
+			err = e
+			return param
+		}
+		if v != math.Trunc(v) {
+			err = fmt.Errorf("%s is not an integer", path)
+			return param
+		}
+		// Parenthesized for `1d20 - @penalty` to hold with negative values.
+		if v < 0 {
+			return "(" + strconv.Itoa(int(v)) + ")"
+		}
+		return strconv.Itoa(int(v))
+	})
+	if err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// macroCharacter finds the character of the macro to run, the one of the user with a macro of this name
+// when the command does not give it.
+func macroCharacter(table *Table, cmd *RunMacroCmd, ctx context.Context) (*Character, error) {
+	user := app_context.GetAuthUser(ctx)
+	if cmd.Character != "" {
+		return editableCharacter(table, cmd.Character, ctx)
+	}
+	var res *Character
+	for idx := range table.Characters {
+		c := &table.Characters[idx]
+		if c.Player != user || c.Retired || c.Macro(cmd.Name) == nil {
+			continue
+		}
+		if res != nil {
+			return nil, lib.HttpBadRequest(fmt.Errorf("several of your characters have the macro %s, give the character", cmd.Name))
+		}
+		res = c
+	}
+	if res == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("macro %s not found", cmd.Name))
+	}
+	return res, nil
+}
+
+// Commands.
+
+func (s *tableServices) SaveMacro(table *Table, cmd *SaveMacroCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character, err := editableCharacter(table, cmd.Character, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !macroNamePattern.MatchString(cmd.Name) {
+		return nil, lib.HttpBadRequest(fmt.Errorf("name must have between 1 and 32 letters, digits, - or _"))
+	}
+	// Checked with the current sheet, the sheet may still change before the macro is run.
+	expanded, err := character.expandMacro(cmd.Expression)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	if _, err := dice.Parse(expanded); err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	macro := Macro{Name: cmd.Name, Expression: strings.TrimSpace(cmd.Expression)}
+	macros := make([]Macro, 0, len(character.Macros)+1)
+	replaced := false
+	for _, m := range character.Macros {
+		if m.Name == macro.Name {
+			m, replaced = macro, true
+		}
+		macros = append(macros, m)
+	}
+	if !replaced {
+		if len(macros) >= maxMacrosByCharacter {
+			return nil, lib.HttpBadRequest(fmt.Errorf("a character has at most %d macros", maxMacrosByCharacter))
+		}
+		macros = append(macros, macro)
+	}
+
+	evt := &MacroSaved{EventBase: NewEventBase(table.Id, character.Audience(), by), Character: character.Id, Macro: macro}
+	update := bson.M{"$set": bson.M{"characters.$[c].macros": macros}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"c.id": character.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) DeleteMacro(table *Table, cmd *DeleteMacroCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	character, err := editableCharacter(table, cmd.Character, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if character.Macro(cmd.Name) == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("macro %s not found", cmd.Name))
+	}
+
+	evt := &MacroDeleted{EventBase: NewEventBase(table.Id, character.Audience(), by), Character: character.Id, Name: cmd.Name}
+	update := bson.M{"$pull": bson.M{"characters.$[c].macros": bson.M{"name": cmd.Name}}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"c.id": character.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// RunMacro rolls the macro as a roll of the user, with the expanded expression.
+func (s *tableServices) RunMacro(table *Table, cmd *RunMacroCmd, ctx context.Context) (Event, error) {
+	if err := s.authorize(table, RollDiceKind, ctx); err != nil {
+		return nil, err
+	}
+	if cmd.Name == "" {
+		return nil, lib.HttpBadRequest(fmt.Errorf("name is required"))
+	}
+	character, err := macroCharacter(table, cmd, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if character.Retired {
+		return nil, lib.HttpConflict(fmt.Errorf("character %s is retired", character.Id))
+	}
+	macro := character.Macro(cmd.Name)
+	if macro == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("macro %s not found", cmd.Name))
+	}
+	expanded, err := character.expandMacro(macro.Expression)
+	if err != nil {
+		return nil, lib.HttpBadRequest(fmt.Errorf("macro %s: %v", macro.Name, err))
+	}
+	roll := &RollDiceCmd{Expression: expanded, Label: cmd.Label, Discussion: cmd.Discussion, Visibility: cmd.Visibility}
+	return s.roll(table, roll, character, macro, ctx)
+}
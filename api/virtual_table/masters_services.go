@@ -0,0 +1,79 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TransferOwnership gives the table to another member, the previous owner stays as co-master.
+func (s *tableServices) TransferOwnership(table *Table, cmd *TransferOwnershipCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	// The settings cannot delegate the ownership.
+	if table.RoleOf(by) != OwnerRole {
+		return nil, &PermissionDeniedError{Table: table.Id.Hex(), User: by, Role: table.RoleOf(by), Command: cmd.Kind()}
+	}
+	if cmd.User == by {
+		return nil, lib.HttpBadRequest(fmt.Errorf("already the owner of the table"))
+	}
+	if table.RoleOf(cmd.User) == "" {
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not a member of the table", cmd.User))
+	}
+
+	evt := &OwnershipTransferred{EventBase: NewEventBase(table.Id, []string{"*"}, by), From: by, To: cmd.User}
+	coMasters := []string{by}
+	for _, u := range table.CoMasters {
+		if u != cmd.User {
+			coMasters = append(coMasters, u)
+		}
+	}
+	update := bson.M{
+		"$set":  bson.M{"master": cmd.User, "coMasters": coMasters},
+		"$pull": bson.M{"players": cmd.User, "spectators": cmd.User},
+	}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// AddCoMaster promotes a player or a spectator of the table.
+func (s *tableServices) AddCoMaster(table *Table, cmd *AddCoMasterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	switch table.RoleOf(cmd.User) {
+	case "":
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not a member of the table", cmd.User))
+	case OwnerRole, CoMasterRole:
+		return nil, lib.HttpConflict(fmt.Errorf("%s is already a master of the table", cmd.User))
+	}
+
+	evt := &CoMasterAdded{EventBase: NewEventBase(table.Id, []string{"*"}, by), Player: cmd.User}
+	update := bson.M{
+		"$addToSet": bson.M{"coMasters": cmd.User},
+		"$pull":     bson.M{"players": cmd.User, "spectators": cmd.User},
+	}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// RemoveCoMaster demotes a co-master, who stays at the table as player.
+func (s *tableServices) RemoveCoMaster(table *Table, cmd *RemoveCoMasterCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if table.RoleOf(cmd.User) != CoMasterRole {
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not a co-master of the table", cmd.User))
+	}
+
+	evt := &CoMasterRemoved{EventBase: NewEventBase(table.Id, []string{"*"}, by), Player: cmd.User}
+	update := bson.M{
+		"$pull":     bson.M{"coMasters": cmd.User},
+		"$addToSet": bson.M{"players": cmd.User},
+	}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,54 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+func (s *tableServices) SendMessage(table *Table, cmd *SendMessageCmd, ctx context.Context) (Event, error) {
+	if roll, ok := rollCommand(cmd.Message); ok {
+		if err := s.authorize(table, RollDiceKind, ctx); err != nil {
+			return nil, err
+		}
+		roll.Discussion = cmd.Discussion
+		return s.RollDice(table, roll, ctx)
+	}
+	if run, ok := macroCommand(cmd.Message); ok {
+		if err := s.authorize(table, RunMacroKind, ctx); err != nil {
+			return nil, err
+		}
+		run.Discussion = cmd.Discussion
+		return s.RunMacro(table, run, ctx)
+	}
+	user := app_context.GetAuthUser(ctx)
+	discussion := table.Discussion(cmd.Discussion)
+	if discussion == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("discussion %s not found", cmd.Discussion))
+	}
+	if !table.Includes(discussion.Between, user) {
+		return nil, lib.HttpForbidden(fmt.Errorf("not allowed to write in discussion %s", cmd.Discussion))
+	}
+	if until := table.MutedUntil(user, discussion.Id, time.Now()); until != nil {
+		return nil, lib.HttpForbidden(fmt.Errorf("muted in discussion %s until %s", cmd.Discussion, until.Format(time.RFC3339)))
+	}
+	if cmd.Message == "" {
+		return nil, lib.HttpBadRequest(fmt.Errorf("message is required"))
+	}
+
+	evt := &PlayerSentMessage{EventBase: NewEventBase(table.Id, discussion.Between, user), Player: user, Discussion: discussion.Id, Message: cmd.Message}
+	var err error
+	if discussion.Persistent {
+		update := bson.M{"$push": bson.M{"discussions.$[d].messages": &Message{Content: cmd.Message, By: user, At: evt.GetAt()}}}
+		err = s.commit(ctx, table.Id, evt, update, bson.M{"d.id": discussion.Id})
+	} else {
+		err = s.commit(ctx, table.Id, evt, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -1,22 +1,57 @@
 package virtual_table
 
 import (
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/api/user"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"strings"
 	"time"
 )
 
-type User struct {
-	Id      primitive.ObjectID `json:"id" bson:"_id"`
-	Name    string             `json:"name" bson:"name"`
-	Picture string             `json:"picture" bson:"picture"`
-}
-
 type Character struct {
 	Id      string `json:"id" bson:"id"`
 	Table   string `json:"table" bson:"table"`
 	Player  string `json:"player" bson:"player"`
 	Name    string `json:"name" bson:"name"`
 	Picture string `json:"picture" bson:"picture"`
+	// Hidden characters, like the NPCs, are visible only to the masters and their player.
+	Hidden bool `json:"hidden" bson:"hidden"`
+	// Retired characters are kept for the history of the table.
+	Retired bool `json:"retired" bson:"retired"`
+	// Sheet validated by the game system of the table.
+	Sheet map[string]interface{} `json:"sheet" bson:"sheet"`
+	// Fields computed from the sheet by the formulas of the game system.
+	Derived map[string]float64 `json:"derived" bson:"derived"`
+	// Saved rolls of the character.
+	Macros []Macro `json:"macros" bson:"macros"`
+}
+
+// Macro is a named dice expression of a character, its parameters like `@proficiencyBonus` or `@abilities.str`
+// are the derived fields and the numbers of the sheet, read when the macro is run.
+type Macro struct {
+	Name       string `json:"name" bson:"name"`
+	Expression string `json:"expression" bson:"expression"`
+}
+
+func (c *Character) Macro(name string) *Macro {
+	for idx := range c.Macros {
+		if c.Macros[idx].Name == name {
+			return &c.Macros[idx]
+		}
+	}
+	return nil
+}
+
+// Audience returns the users who can see the character.
+func (c *Character) Audience() []string {
+	if !c.Hidden {
+		return []string{"*"}
+	}
+	if c.Player != "" {
+		return []string{MastersAudience, c.Player}
+	}
+	return []string{MastersAudience}
 }
 
 type Message struct {
@@ -31,18 +66,359 @@ type Discussion struct {
 	Persistent bool      `json:"persistent" bson:"persistent"`
 	Between    []string  `json:"between" bson:"between"`
 	Messages   []Message `json:"messages" bson:"messages"`
+	// Template discussions are copied with their messages when the table is cloned.
+	Template bool `json:"template" bson:"template"`
+}
+
+// Handout is a document, like a letter or a map, written by the masters and shared with some players.
+type Handout struct {
+	Id   string `json:"id" bson:"id"`
+	Name string `json:"name" bson:"name"`
+	// Text of the handout, or the url of a picture.
+	Content string `json:"content" bson:"content"`
+	// Users the handout is shared with, `*` for everyone. The masters always see it.
+	SharedWith []string `json:"sharedWith" bson:"sharedWith"`
+}
+
+// Audience returns the users who can see the handout.
+func (h *Handout) Audience() []string {
+	return append([]string{MastersAudience}, h.SharedWith...)
+}
+
+// TableBot is a bot invited at a table, allowed to run only some commands.
+type TableBot struct {
+	Id       string        `json:"id" bson:"id"`
+	Commands []CommandKind `json:"commands" bson:"commands"`
+}
+
+func (b *TableBot) Allows(kind CommandKind) bool {
+	for _, k := range b.Commands {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+type TableSettings struct {
+	// Overrides of the roles allowed to run a command.
+	Permissions map[CommandKind][]Role `json:"permissions" bson:"permissions"`
+	// Discoverable tables accept join requests from any user.
+	Discoverable bool `json:"discoverable" bson:"discoverable"`
+	// Templates are listed apart, to be cloned.
+	Template bool `json:"template" bson:"template"`
+	// Id of the game system describing the character sheets, any sheet is accepted when empty.
+	GameSystem string `json:"gameSystem" bson:"gameSystem"`
+}
+
+type Schedule struct {
+	// Free description, like `every other friday at 8pm`.
+	Description string     `json:"description" bson:"description"`
+	NextSession *time.Time `json:"nextSession,omitempty" bson:"nextSession,omitempty"`
+}
+
+// Lobby describes a discoverable table to the users looking for one.
+type Lobby struct {
+	Description string   `json:"description" bson:"description"`
+	GameSystem  string   `json:"gameSystem" bson:"gameSystem"`
+	Language    string   `json:"language" bson:"language"`
+	Tags        []string `json:"tags" bson:"tags"`
+	// Maximum number of players, unlimited when 0.
+	PlayerCap int      `json:"playerCap" bson:"playerCap"`
+	Schedule  Schedule `json:"schedule" bson:"schedule"`
+}
+
+// Mute forbids a member to write in a discussion until a date.
+type Mute struct {
+	User       string    `json:"user" bson:"user"`
+	Discussion string    `json:"discussion" bson:"discussion"`
+	Until      time.Time `json:"until" bson:"until"`
 }
 
 type Table struct {
 	Id          primitive.ObjectID `json:"id" bson:"_id"`
 	Name        string             `json:"name" bson:"name"`
 	Master      string             `json:"master" bson:"master"`
+	CoMasters   []string           `json:"coMasters" bson:"coMasters"`
 	Players     []string           `json:"players" bson:"players"`
+	Spectators  []string           `json:"spectators" bson:"spectators"`
 	Characters  []Character        `json:"characters" bson:"characters"`
 	Discussions []Discussion       `json:"discussions" bson:"discussions"`
+	Handouts    []Handout          `json:"handouts" bson:"handouts"`
+	Bots        []TableBot         `json:"bots" bson:"bots"`
+	Settings    TableSettings      `json:"settings" bson:"settings"`
+	Lobby       Lobby              `json:"lobby" bson:"lobby"`
+	// Users who cannot join the table anymore.
+	Banned []string `json:"banned" bson:"banned"`
+	Mutes  []Mute   `json:"mutes" bson:"mutes"`
+	// Archived tables are read-only, deleted ones are in the trash until purged.
+	ArchivedAt *time.Time `json:"archivedAt,omitempty" bson:"archivedAt,omitempty"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	// Combat encounter in progress.
+	Encounter *Encounter `json:"encounter,omitempty" bson:"encounter,omitempty"`
+}
+
+func (t *Table) IsMember(user string) bool {
+	return t.RoleOf(user) != "" || t.Bot(user) != nil
+}
+
+func (t *Table) IsBanned(user string) bool {
+	for _, u := range t.Banned {
+		if u == user {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFull tells whether the player cap of the lobby is reached.
+func (t *Table) IsFull() bool {
+	return t.Lobby.PlayerCap > 0 && len(t.Players) >= t.Lobby.PlayerCap
+}
+
+// MutedUntil returns the end of the mute of the user in the discussion, nil when it is not muted.
+func (t *Table) MutedUntil(user string, discussion string, now time.Time) *time.Time {
+	for _, m := range t.Mutes {
+		if m.User == user && m.Discussion == discussion && m.Until.After(now) {
+			return &m.Until
+		}
+	}
+	return nil
+}
+
+func (t *Table) Bot(id string) *TableBot {
+	for idx := range t.Bots {
+		if t.Bots[idx].Id == id {
+			return &t.Bots[idx]
+		}
+	}
+	return nil
+}
+
+func (t *Table) Character(id string) *Character {
+	for idx := range t.Characters {
+		if t.Characters[idx].Id == id {
+			return &t.Characters[idx]
+		}
+	}
+	return nil
+}
+
+func (t *Table) Handout(id string) *Handout {
+	for idx := range t.Handouts {
+		if t.Handouts[idx].Id == id {
+			return &t.Handouts[idx]
+		}
+	}
+	return nil
+}
+
+func (t *Table) Discussion(id string) *Discussion {
+	for idx := range t.Discussions {
+		if t.Discussions[idx].Id == id {
+			return &t.Discussions[idx]
+		}
+	}
+	return nil
+}
+
+// MastersAudience stands for the owner and the co-masters in the `between` of a discussion or the `allowUsers`
+// of an event. It is resolved when read, so that a new master sees what was written before.
+const MastersAudience = "@masters"
+
+// IsMaster tells whether the user is the owner or a co-master of the table.
+func (t *Table) IsMaster(user string) bool {
+	role := t.RoleOf(user)
+	return role == OwnerRole || role == CoMasterRole
+}
+
+// Includes tells whether the audience, like the `between` of a discussion or the `allowUsers` of an event,
+// includes the user.
+func (t *Table) Includes(audience []string, user string) bool {
+	for _, u := range audience {
+		if u == "*" || u == user || (u == MastersAudience && t.IsMaster(user)) {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicTable is the projection of a table visible by the users who are not members.
+type PublicTable struct {
+	Id           primitive.ObjectID `json:"id" bson:"_id"`
+	Name         string             `json:"name" bson:"name"`
+	Master       string             `json:"master" bson:"master"`
+	Lobby        Lobby              `json:"lobby" bson:"lobby"`
+	PlayersCount int                `json:"playersCount" bson:"playersCount"`
+	// Free seats, -1 when the number of players is unlimited.
+	FreeSeats int                     `json:"freeSeats" bson:"-"`
+	Users     map[string]user.Profile `json:"users" bson:"-"`
 }
 
 type TableWithEvents struct {
 	Table
 	Events []Event `json:"events" bson:"events"`
+	// Profiles of the masters and the players.
+	Users map[string]user.Profile `json:"users" bson:"-"`
+}
+
+// Masters returns the owner and the co-masters of the table.
+func (t *Table) Masters() []string {
+	return append([]string{t.Master}, t.CoMasters...)
+}
+
+// Invite lets users join a table with a role, until it expires, is revoked or is used up.
+type Invite struct {
+	Id        primitive.ObjectID `json:"id" bson:"_id"`
+	Token     string             `json:"token" bson:"token"`
+	TableId   primitive.ObjectID `json:"tableId" bson:"tableId"`
+	Role      Role               `json:"role" bson:"role"`
+	By        string             `json:"by" bson:"by"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	ExpiresAt time.Time          `json:"expiresAt" bson:"expiresAt"`
+	// Maximum number of uses, unlimited when 0.
+	MaxUses   int        `json:"maxUses" bson:"maxUses"`
+	Uses      int        `json:"uses" bson:"uses"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
+
+type JoinRequestStatus string
+
+const (
+	PendingJoinRequest  JoinRequestStatus = "pending"
+	ApprovedJoinRequest JoinRequestStatus = "approved"
+	RejectedJoinRequest JoinRequestStatus = "rejected"
+)
+
+// JoinRequest is submitted by a user to join a discoverable table, the masters approve or reject it.
+type JoinRequest struct {
+	Id        primitive.ObjectID `json:"id" bson:"_id"`
+	TableId   primitive.ObjectID `json:"tableId" bson:"tableId"`
+	User      string             `json:"user" bson:"user"`
+	Message   string             `json:"message" bson:"message"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	Status    JoinRequestStatus  `json:"status" bson:"status"`
+	DecidedBy string             `json:"decidedBy,omitempty" bson:"decidedBy,omitempty"`
+	DecidedAt *time.Time         `json:"decidedAt,omitempty" bson:"decidedAt,omitempty"`
+	Reason    string             `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// DiceSession commits the server to a seed for provably fair rolls, the seed is revealed at the end of the session.
+type DiceSession struct {
+	Id             primitive.ObjectID `json:"id" bson:"_id"`
+	TableId        primitive.ObjectID `json:"tableId" bson:"tableId"`
+	ServerSeedHash string             `json:"serverSeedHash" bson:"serverSeedHash"`
+	// Secret until the end of the session.
+	ServerSeed  string       `json:"-" bson:"serverSeed"`
+	ClientSeeds []ClientSeed `json:"clientSeeds" bson:"clientSeeds"`
+	// Number of rolls of the session.
+	Nonce     int        `json:"nonce" bson:"nonce"`
+	StartedAt time.Time  `json:"startedAt" bson:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt" bson:"endedAt"`
+}
+
+// ClientSeed is the contribution of a player to the rolls of a dice session.
+type ClientSeed struct {
+	Player string `json:"player" bson:"player"`
+	Seed   string `json:"seed" bson:"seed"`
+}
+
+// CombinedClientSeed joins the seeds of the players, sorted by player.
+func (s *DiceSession) CombinedClientSeed() string {
+	seeds := append([]ClientSeed{}, s.ClientSeeds...)
+	sort.Slice(seeds, func(i, j int) bool { return seeds[i].Player < seeds[j].Player })
+	parts := make([]string, len(seeds))
+	for i, seed := range seeds {
+		parts[i] = seed.Player + "=" + seed.Seed
+	}
+	return strings.Join(parts, ";")
+}
+
+// FairRoll tells how a roll of a dice session was drawn.
+type FairRoll struct {
+	Session        string `json:"session" bson:"session"`
+	ServerSeedHash string `json:"serverSeedHash" bson:"serverSeedHash"`
+	ClientSeed     string `json:"clientSeed" bson:"clientSeed"`
+	Nonce          int    `json:"nonce" bson:"nonce"`
+}
+
+// RollVerification is a roll drawn again from the seeds revealed at the end of its session.
+type RollVerification struct {
+	Roll string `json:"roll"`
+	FairRoll
+	ServerSeed string      `json:"serverSeed"`
+	Expected   dice.Result `json:"expected"`
+	// Valid when the server seed matches its commitment and draws the same dice.
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// TableDiceStats sums up the dice rolled at a table, as a whole and by player.
+type TableDiceStats struct {
+	Table   *dice.Tally            `json:"table"`
+	Players map[string]*dice.Tally `json:"players"`
+}
+
+type CombatantStatus string
+
+const (
+	// Delaying and ready combatants act later in the round, their status expires when their turn comes again.
+	DelayingCombatant CombatantStatus = "delaying"
+	ReadyCombatant    CombatantStatus = "ready"
+)
+
+// Combatant is a character, or a NPC without sheet, in the turn order of an encounter.
+type Combatant struct {
+	Id string `json:"id" bson:"id"`
+	// Character of the combatant, empty for the NPCs added by name.
+	Character string `json:"character" bson:"character"`
+	Player    string `json:"player" bson:"player"`
+	Name      string `json:"name" bson:"name"`
+	// Hidden combatants are visible only to the masters and their player.
+	Hidden     bool `json:"hidden" bson:"hidden"`
+	Initiative int  `json:"initiative" bson:"initiative"`
+	// First tie-break, the initiative modifier of the character.
+	Modifier int `json:"modifier" bson:"modifier"`
+	// Last tie-break, drawn when the combatant is added.
+	TieBreak int `json:"tieBreak" bson:"tieBreak"`
+	// Roll of the initiative, nil when it was entered.
+	Roll   *dice.Result    `json:"roll" bson:"roll"`
+	Status CombatantStatus `json:"status" bson:"status"`
+	// Trigger of the readied action.
+	Trigger string `json:"trigger" bson:"trigger"`
+}
+
+// Audience returns the users who can see the combatant.
+func (c *Combatant) Audience() []string {
+	if !c.Hidden {
+		return []string{"*"}
+	}
+	if c.Player != "" {
+		return []string{MastersAudience, c.Player}
+	}
+	return []string{MastersAudience}
+}
+
+// Encounter is a fight of a table, its combatants act in turn by decreasing initiative.
+type Encounter struct {
+	Id   string `json:"id" bson:"id"`
+	Name string `json:"name" bson:"name"`
+	// Dice expression of the rolled initiatives, to which the modifier is added.
+	InitiativeDice string `json:"initiativeDice" bson:"initiativeDice"`
+	// Combatants in turn order.
+	Combatants []Combatant `json:"combatants" bson:"combatants"`
+	// 0 until the first turn.
+	Round int `json:"round" bson:"round"`
+	// Id of the combatant whose turn it is.
+	Current   string    `json:"current" bson:"current"`
+	StartedAt time.Time `json:"startedAt" bson:"startedAt"`
+}
+
+func (e *Encounter) Combatant(id string) *Combatant {
+	for idx := range e.Combatants {
+		if e.Combatants[idx].Id == id {
+			return &e.Combatants[idx]
+		}
+	}
+	return nil
 }
@@ -0,0 +1,126 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+// checkModeration verifies that the authenticated user can moderate the target:
+// nobody moderates the owner nor themselves, and only the owner moderates the co-masters.
+// Bots have no role in the table, they are removed with RemoveBot rather than kicked or banned.
+func checkModeration(table *Table, target string, ctx context.Context) error {
+	by := app_context.GetAuthUser(ctx)
+	if target == "" {
+		return lib.HttpBadRequest(fmt.Errorf("player is required"))
+	}
+	if target == by {
+		return lib.HttpBadRequest(fmt.Errorf("cannot moderate yourself"))
+	}
+	if table.Bot(target) != nil {
+		return lib.HttpBadRequest(fmt.Errorf("%s is a bot, remove it from the table instead", target))
+	}
+	switch table.RoleOf(target) {
+	case OwnerRole:
+		return lib.HttpForbidden(fmt.Errorf("the owner of the table cannot be moderated"))
+	case CoMasterRole:
+		if table.RoleOf(by) != OwnerRole {
+			return lib.HttpForbidden(fmt.Errorf("only the owner can moderate a co-master"))
+		}
+	}
+	return nil
+}
+
+// removeMember pulls the user from every role of the table.
+func removeMember(user string) bson.M {
+	return bson.M{"coMasters": user, "players": user, "spectators": user}
+}
+
+func (s *tableServices) KickPlayer(table *Table, cmd *KickPlayerCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if err := checkModeration(table, cmd.Player, ctx); err != nil {
+		return nil, err
+	}
+	if table.RoleOf(cmd.Player) == "" {
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not a member of the table", cmd.Player))
+	}
+	evt := &PlayerKicked{EventBase: NewEventBase(table.Id, []string{"*"}, by), Player: cmd.Player}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$pull": removeMember(cmd.Player)}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// BanPlayer removes the user from the table, the user cannot redeem invites nor request to join anymore.
+func (s *tableServices) BanPlayer(table *Table, cmd *BanPlayerCmd, ctx context.Context) (Event, error) {
+	db := app_context.GetMongodb(ctx)
+	by := app_context.GetAuthUser(ctx)
+	if err := checkModeration(table, cmd.Player, ctx); err != nil {
+		return nil, err
+	}
+	if table.IsBanned(cmd.Player) {
+		return nil, lib.HttpConflict(fmt.Errorf("%s is already banned", cmd.Player))
+	}
+	// Close the user's pending join requests.
+	if _, err := db.Collection(joinRequestsCollectionName).UpdateMany(ctx,
+		bson.M{"tableId": table.Id, "user": cmd.Player, "status": PendingJoinRequest},
+		bson.M{"$set": bson.M{"status": RejectedJoinRequest, "decidedBy": by, "decidedAt": time.Now(), "reason": cmd.Reason}},
+	); err != nil {
+		return nil, err
+	}
+	evt := &PlayerBanned{EventBase: NewEventBase(table.Id, []string{MastersAudience, cmd.Player}, by), Player: cmd.Player, Reason: cmd.Reason}
+	if table.RoleOf(cmd.Player) != "" {
+		evt.AllowUsers = []string{"*"}
+	}
+	update := bson.M{"$pull": removeMember(cmd.Player), "$addToSet": bson.M{"banned": cmd.Player}}
+	if err := s.commit(ctx, table.Id, evt, update); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func (s *tableServices) UnbanPlayer(table *Table, cmd *UnbanPlayerCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if !table.IsBanned(cmd.Player) {
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not banned", cmd.Player))
+	}
+	evt := &PlayerUnbanned{EventBase: NewEventBase(table.Id, []string{MastersAudience, cmd.Player}, by), Player: cmd.Player}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$pull": bson.M{"banned": cmd.Player}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+// MutePlayer forbids the user to write in the discussion until the given date.
+func (s *tableServices) MutePlayer(table *Table, cmd *MutePlayerCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	if err := checkModeration(table, cmd.Player, ctx); err != nil {
+		return nil, err
+	}
+	if table.RoleOf(cmd.Player) == "" {
+		return nil, lib.HttpNotFound(fmt.Errorf("%s is not a member of the table", cmd.Player))
+	}
+	if table.Discussion(cmd.Discussion) == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("discussion %s not found", cmd.Discussion))
+	}
+	now := time.Now()
+	if !cmd.Until.After(now) {
+		return nil, lib.HttpBadRequest(fmt.Errorf("until must be in the future"))
+	}
+
+	// Replace the previous mute of the player in the discussion and forget the expired ones.
+	mutes := []Mute{{User: cmd.Player, Discussion: cmd.Discussion, Until: cmd.Until}}
+	for _, m := range table.Mutes {
+		if m.Until.After(now) && (m.User != cmd.Player || m.Discussion != cmd.Discussion) {
+			mutes = append(mutes, m)
+		}
+	}
+	evt := &PlayerMuted{EventBase: NewEventBase(table.Id, []string{MastersAudience, cmd.Player}, by), Player: cmd.Player, Discussion: cmd.Discussion, Until: cmd.Until}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"mutes": mutes}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,147 @@
+package virtual_table
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Role string
+
+const (
+	OwnerRole     Role = "owner"
+	CoMasterRole  Role = "co-master"
+	PlayerRole    Role = "player"
+	SpectatorRole Role = "spectator"
+)
+
+// Roles allowed to run each command, the owner is always allowed.
+// Commands missing here are allowed only to the owner.
+var defaultPermissions = map[CommandKind][]Role{
+	SendMessageKind:    {CoMasterRole, PlayerRole},
+	InviteBotKind:      {CoMasterRole},
+	RemoveBotKind:      {CoMasterRole},
+	UpdateSettingsKind: {},
+	CreateInviteKind:   {CoMasterRole},
+	RevokeInviteKind:   {CoMasterRole},
+	UpdateLobbyKind:    {CoMasterRole},
+	// Join requests
+	ApproveJoinRequestKind: {CoMasterRole},
+	RejectJoinRequestKind:  {CoMasterRole},
+	// Characters, the players manage only their own ones.
+	CreateCharacterKind: {CoMasterRole, PlayerRole},
+	UpdateCharacterKind: {CoMasterRole, PlayerRole},
+	AssignCharacterKind: {CoMasterRole},
+	RetireCharacterKind: {CoMasterRole, PlayerRole},
+	DeleteCharacterKind: {CoMasterRole},
+	SaveMacroKind:       {CoMasterRole, PlayerRole},
+	DeleteMacroKind:     {CoMasterRole, PlayerRole},
+	RunMacroKind:        {CoMasterRole, PlayerRole},
+	// Templates
+	CloneTableKind:       {CoMasterRole},
+	UpdateDiscussionKind: {CoMasterRole},
+	// Handouts
+	CreateHandoutKind: {CoMasterRole},
+	ShareHandoutKind:  {CoMasterRole},
+	DeleteHandoutKind: {CoMasterRole},
+	// Lifecycle
+	ArchiveTableKind: {},
+	DeleteTableKind:  {},
+	RestoreTableKind: {},
+	// Masters, the ownership is always transferred by the owner.
+	AddCoMasterKind:    {},
+	RemoveCoMasterKind: {},
+	// Moderation
+	KickPlayerKind:  {CoMasterRole},
+	BanPlayerKind:   {CoMasterRole},
+	UnbanPlayerKind: {CoMasterRole},
+	MutePlayerKind:  {CoMasterRole},
+	// Dice
+	RollDiceKind: {CoMasterRole, PlayerRole},
+	// The masters reveal the hidden rolls, the players only their own self rolls.
+	RevealRollKind:       {CoMasterRole, PlayerRole},
+	StartDiceSessionKind: {CoMasterRole},
+	AddClientSeedKind:    {CoMasterRole, PlayerRole},
+	EndDiceSessionKind:   {CoMasterRole},
+	// Encounters, the players act only for their own combatants.
+	StartEncounterKind:  {CoMasterRole},
+	AddCombatantKind:    {CoMasterRole, PlayerRole},
+	RemoveCombatantKind: {CoMasterRole},
+	NextTurnKind:        {CoMasterRole, PlayerRole},
+	PreviousTurnKind:    {CoMasterRole},
+	DelayTurnKind:       {CoMasterRole, PlayerRole},
+	ReadyActionKind:     {CoMasterRole, PlayerRole},
+	ActNowKind:          {CoMasterRole, PlayerRole},
+	EndEncounterKind:    {CoMasterRole},
+}
+
+// Commands which only the owner runs, whatever the settings. They are never delegated to bots.
+var ownerOnlyKinds = map[CommandKind]bool{
+	CreateTableKind:       true,
+	UpdateSettingsKind:    true,
+	ArchiveTableKind:      true,
+	DeleteTableKind:       true,
+	RestoreTableKind:      true,
+	TransferOwnershipKind: true,
+	AddCoMasterKind:       true,
+	RemoveCoMasterKind:    true,
+}
+
+// PermissionDeniedError is returned when the role of the user at the table does not allow the command.
+type PermissionDeniedError struct {
+	Table   string
+	User    string
+	Role    Role
+	Command CommandKind
+}
+
+func (e *PermissionDeniedError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("%s is not a member of table %s and cannot run %s", e.User, e.Table, e.Command)
+	}
+	return fmt.Sprintf("role %s cannot run %s at table %s", e.Role, e.Command, e.Table)
+}
+
+func (*PermissionDeniedError) HttpStatus() int { return http.StatusForbidden }
+
+// RoleOf returns the role of the user at the table, empty when it is not a member.
+func (t *Table) RoleOf(user string) Role {
+	if t.Master == user {
+		return OwnerRole
+	}
+	for _, u := range t.CoMasters {
+		if u == user {
+			return CoMasterRole
+		}
+	}
+	for _, u := range t.Players {
+		if u == user {
+			return PlayerRole
+		}
+	}
+	for _, u := range t.Spectators {
+		if u == user {
+			return SpectatorRole
+		}
+	}
+	return ""
+}
+
+// Allows tells whether the role can run the command, with the overrides of the settings.
+func (s *TableSettings) Allows(role Role, kind CommandKind) bool {
+	if role == OwnerRole {
+		return true
+	}
+	if role == "" || ownerOnlyKinds[kind] {
+		return false
+	}
+	roles, ok := s.Permissions[kind]
+	if !ok {
+		roles = defaultPermissions[kind]
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
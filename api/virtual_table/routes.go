@@ -1,15 +1,18 @@
 package virtual_table
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	"github.com/gorilla/websocket"
-	"github.com/nats-io/nats.go"
 	"github.com/rpg-tools/toolbox-services/app_context"
 	"github.com/rpg-tools/toolbox-services/lib"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,9 +27,54 @@ const (
 	pingPeriod = (pongWait * 9) / 10
 
 	// Maximum message size allowed from peer.
-	// maxMessageSize = 512
+	maxMessageSize = 32 * 1024
 )
 
+// commandError is replied on the socket when a command sent on it fails.
+type commandError struct {
+	Kind    string        `json:"_kind"`
+	Command CommandKind   `json:"command,omitempty"`
+	Error   lib.HttpError `json:"error"`
+}
+
+// readCommands runs the commands sent on the socket until it is closed, their errors are sent to replies.
+// The events of the commands are received as any other event of the table.
+func readCommands(services *tableServices, conn *websocket.Conn, tableId string, replies chan<- []byte, ctx context.Context) {
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error { return conn.SetReadDeadline(time.Now().Add(pongWait)) })
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Error(err)
+			}
+			return
+		}
+		res := &commandError{Kind: "error"}
+		cmd, err := ReadCommandJson(data)
+		if err != nil {
+			res.Error = lib.HttpBadRequest(err)
+		} else if _, err = services.Handle(tableId, cmd, ctx); err != nil {
+			res.Command = cmd.Kind()
+			res.Error = lib.ToHttpError(err)
+		}
+		if res.Error == nil {
+			continue
+		}
+		reply, err := json.Marshal(res)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		select {
+		case replies <- reply:
+		default:
+			// The socket is too slow or closed, drop the reply.
+		}
+	}
+}
+
 func createTableRoute(services *tableServices) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		d := json.NewDecoder(r.Body)
@@ -49,9 +97,91 @@ func createTableRoute(services *tableServices) http.HandlerFunc {
 
 func findManyTableRoute(services *tableServices) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// TODO manage query filter.
 		ctx := r.Context()
-		tables, err := services.Search(nil, ctx)
+		params := r.URL.Query()
+		query := SearchTablesQuery{
+			Name:      params.Get("name"),
+			Archived:  params.Get("archived") == "true",
+			Deleted:   params.Get("deleted") == "true",
+			Templates: params.Get("templates") == "true",
+		}
+		tables, err := services.Search(query, ctx)
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(tables, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+// readLobbyQuery reads the filters of the lobby from the query parameters.
+func readLobbyQuery(r *http.Request) (LobbyQuery, error) {
+	params := r.URL.Query()
+	query := LobbyQuery{
+		Text:       params.Get("text"),
+		GameSystem: params.Get("gameSystem"),
+		Language:   params.Get("language"),
+		Tags:       make([]string, 0),
+	}
+	// Tags are given either comma separated or repeated.
+	for _, value := range params["tags"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				query.Tags = append(query.Tags, tag)
+			}
+		}
+	}
+	var err error
+	if value := params.Get("freeSeats"); value != "" {
+		if query.FreeSeats, err = strconv.ParseBool(value); err != nil {
+			return query, fmt.Errorf("invalid freeSeats: %v", err)
+		}
+	}
+	if value := params.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			return query, fmt.Errorf("invalid limit: %v", err)
+		}
+	}
+	if value := params.Get("offset"); value != "" {
+		if query.Offset, err = strconv.Atoi(value); err != nil || query.Offset < 0 {
+			return query, fmt.Errorf("invalid offset")
+		}
+	}
+	return query, nil
+}
+
+// readDiceStatsQuery reads the filters of the dice statistics from the query parameters, the dates are RFC 3339.
+func readDiceStatsQuery(r *http.Request) (DiceStatsQuery, error) {
+	params := r.URL.Query()
+	query := DiceStatsQuery{Session: params.Get("session")}
+	if value := params.Get("from"); value != "" {
+		from, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, fmt.Errorf("invalid from: %v", err)
+		}
+		query.From = &from
+	}
+	if value := params.Get("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, fmt.Errorf("invalid to: %v", err)
+		}
+		query.To = &to
+	}
+	return query, nil
+}
+
+func lobbyRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := readLobbyQuery(r)
+		if err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		tables, err := services.Lobby(query, r.Context())
 		if err != nil {
 			_ = render.Render(w, r, lib.ToHttpError(err))
 			return
@@ -83,6 +213,155 @@ func findOneTableRoute(services *tableServices) http.HandlerFunc {
 	}
 }
 
+func cloneTableRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cmd := &CloneTableCmd{}
+		// The options are optional.
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+				_ = render.Render(w, r, lib.HttpBadRequest(err))
+				return
+			}
+		}
+		res, err := services.Handle(chi.URLParam(r, "id"), cmd, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		// The event is the creation of the new table.
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.GetTableId(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func joinTableRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.RedeemInvite(chi.URLParam(r, "token"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.GetTableId(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func findInvitesRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.Invites(chi.URLParam(r, "id"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func verifyRollRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.VerifyRoll(chi.URLParam(r, "id"), chi.URLParam(r, "roll"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func diceStatsRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := readDiceStatsQuery(r)
+		if err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.DiceStats(chi.URLParam(r, "id"), query, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func findJoinRequestsRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := services.JoinRequests(chi.URLParam(r, "id"), r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponse(res, 200)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+func requestJoinRoute(services *tableServices) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := json.NewDecoder(r.Body)
+		payload := RequestJoinCmd{}
+		if err := d.Decode(&payload); err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.RequestJoin(chi.URLParam(r, "id"), &payload, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.GetId(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
+// jsonCommand reads the command from the body of the request.
+func jsonCommand(supplier func() Command) func(r *http.Request) (Command, error) {
+	return func(r *http.Request) (Command, error) {
+		cmd := supplier()
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	}
+}
+
+// tableCommandRoute runs the command read from the request at the table of the url.
+func tableCommandRoute(services *tableServices, read func(r *http.Request) (Command, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cmd, err := read(r)
+		if err != nil {
+			_ = render.Render(w, r, lib.HttpBadRequest(err))
+			return
+		}
+		res, err := services.Handle(chi.URLParam(r, "id"), cmd, r.Context())
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
+			return
+		}
+		if err = render.Render(w, r, lib.HttpResponseWithId(res.GetId(), res, http.StatusCreated)); err != nil {
+			_ = render.Render(w, r, lib.HttpRenderError(err))
+			return
+		}
+	}
+}
+
 func Route(router chi.Router) {
 	upgrader := websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool { return true },
@@ -92,53 +371,242 @@ func Route(router chi.Router) {
 
 	router.Post("/", createTableRoute(services))
 	router.Get("/", findManyTableRoute(services))
+	router.Get("/lobby", lobbyRoute(services))
 	router.Get("/{id}", findOneTableRoute(services))
+	router.Post("/join/{token}", joinTableRoute(services))
+	router.Get("/{id}/invites", findInvitesRoute(services))
+	router.Post("/{id}/invites", tableCommandRoute(services, jsonCommand(func() Command { return &CreateInviteCmd{} })))
+	router.Delete("/{id}/invites/{invite}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RevokeInviteCmd{Invite: chi.URLParam(r, "invite")}, nil
+	}))
+	router.Get("/{id}/join-requests", findJoinRequestsRoute(services))
+	router.Post("/{id}/join-requests", requestJoinRoute(services))
+	router.Post("/{id}/join-requests/{request}/approve", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &ApproveJoinRequestCmd{Request: chi.URLParam(r, "request")}, nil
+	}))
+	router.Post("/{id}/join-requests/{request}/reject", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &RejectJoinRequestCmd{}
+		// The reason is optional.
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+				return nil, err
+			}
+		}
+		cmd.Request = chi.URLParam(r, "request")
+		return cmd, nil
+	}))
+	router.Post("/{id}/characters", tableCommandRoute(services, jsonCommand(func() Command { return &CreateCharacterCmd{} })))
+	router.Patch("/{id}/characters/{character}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &UpdateCharacterCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Character = chi.URLParam(r, "character")
+		return cmd, nil
+	}))
+	router.Put("/{id}/characters/{character}/player", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &AssignCharacterCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Character = chi.URLParam(r, "character")
+		return cmd, nil
+	}))
+	router.Post("/{id}/characters/{character}/retire", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RetireCharacterCmd{Character: chi.URLParam(r, "character")}, nil
+	}))
+	router.Delete("/{id}/characters/{character}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &DeleteCharacterCmd{Character: chi.URLParam(r, "character")}, nil
+	}))
+	router.Put("/{id}/characters/{character}/macros/{macro}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &SaveMacroCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Character = chi.URLParam(r, "character")
+		cmd.Name = chi.URLParam(r, "macro")
+		return cmd, nil
+	}))
+	router.Delete("/{id}/characters/{character}/macros/{macro}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &DeleteMacroCmd{Character: chi.URLParam(r, "character"), Name: chi.URLParam(r, "macro")}, nil
+	}))
+	router.Post("/{id}/characters/{character}/macros/{macro}/roll", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &RunMacroCmd{}
+		// The label, discussion and visibility are optional.
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+				return nil, err
+			}
+		}
+		cmd.Character = chi.URLParam(r, "character")
+		cmd.Name = chi.URLParam(r, "macro")
+		return cmd, nil
+	}))
+	router.Post("/{id}/clone", cloneTableRoute(services))
+	router.Patch("/{id}/discussions/{discussion}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &UpdateDiscussionCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Discussion = chi.URLParam(r, "discussion")
+		return cmd, nil
+	}))
+	router.Post("/{id}/handouts", tableCommandRoute(services, jsonCommand(func() Command { return &CreateHandoutCmd{} })))
+	router.Put("/{id}/handouts/{handout}/shared-with", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &ShareHandoutCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Handout = chi.URLParam(r, "handout")
+		return cmd, nil
+	}))
+	router.Delete("/{id}/handouts/{handout}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &DeleteHandoutCmd{Handout: chi.URLParam(r, "handout")}, nil
+	}))
+	router.Post("/{id}/archive", tableCommandRoute(services, func(r *http.Request) (Command, error) { return &ArchiveTableCmd{}, nil }))
+	router.Post("/{id}/restore", tableCommandRoute(services, func(r *http.Request) (Command, error) { return &RestoreTableCmd{}, nil }))
+	router.Delete("/{id}", tableCommandRoute(services, func(r *http.Request) (Command, error) { return &DeleteTableCmd{}, nil }))
+	router.Put("/{id}/owner", tableCommandRoute(services, jsonCommand(func() Command { return &TransferOwnershipCmd{} })))
+	router.Post("/{id}/co-masters", tableCommandRoute(services, jsonCommand(func() Command { return &AddCoMasterCmd{} })))
+	router.Delete("/{id}/co-masters/{user}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RemoveCoMasterCmd{User: chi.URLParam(r, "user")}, nil
+	}))
+	router.Post("/{id}/players/{player}/kick", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &KickPlayerCmd{Player: chi.URLParam(r, "player")}, nil
+	}))
+	router.Post("/{id}/players/{player}/ban", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &BanPlayerCmd{}
+		// The reason is optional.
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+				return nil, err
+			}
+		}
+		cmd.Player = chi.URLParam(r, "player")
+		return cmd, nil
+	}))
+	router.Delete("/{id}/players/{player}/ban", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &UnbanPlayerCmd{Player: chi.URLParam(r, "player")}, nil
+	}))
+	router.Post("/{id}/players/{player}/mute", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &MutePlayerCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Player = chi.URLParam(r, "player")
+		return cmd, nil
+	}))
+	router.Put("/{id}/lobby", tableCommandRoute(services, jsonCommand(func() Command { return &UpdateLobbyCmd{} })))
+	router.Patch("/{id}/settings", tableCommandRoute(services, jsonCommand(func() Command { return &UpdateSettingsCmd{} })))
+	router.Post("/{id}/messages", tableCommandRoute(services, jsonCommand(func() Command { return &SendMessageCmd{} })))
+	router.Post("/{id}/rolls", tableCommandRoute(services, jsonCommand(func() Command { return &RollDiceCmd{} })))
+	router.Get("/{id}/rolls/{roll}/verify", verifyRollRoute(services))
+	router.Get("/{id}/stats/dice", diceStatsRoute(services))
+	router.Post("/{id}/encounter", tableCommandRoute(services, jsonCommand(func() Command { return &StartEncounterCmd{} })))
+	router.Delete("/{id}/encounter", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &EndEncounterCmd{}, nil
+	}))
+	router.Post("/{id}/encounter/combatants", tableCommandRoute(services, jsonCommand(func() Command { return &AddCombatantCmd{} })))
+	router.Delete("/{id}/encounter/combatants/{combatant}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RemoveCombatantCmd{Combatant: chi.URLParam(r, "combatant")}, nil
+	}))
+	router.Post("/{id}/encounter/next", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &NextTurnCmd{}, nil
+	}))
+	router.Post("/{id}/encounter/previous", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &PreviousTurnCmd{}, nil
+	}))
+	router.Post("/{id}/encounter/combatants/{combatant}/delay", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &DelayTurnCmd{Combatant: chi.URLParam(r, "combatant")}, nil
+	}))
+	router.Post("/{id}/encounter/combatants/{combatant}/ready", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		cmd := &ReadyActionCmd{}
+		if err := json.NewDecoder(r.Body).Decode(cmd); err != nil {
+			return nil, err
+		}
+		cmd.Combatant = chi.URLParam(r, "combatant")
+		return cmd, nil
+	}))
+	router.Post("/{id}/encounter/combatants/{combatant}/act", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &ActNowCmd{Combatant: chi.URLParam(r, "combatant")}, nil
+	}))
+	router.Post("/{id}/dice-session", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &StartDiceSessionCmd{}, nil
+	}))
+	router.Put("/{id}/dice-session/seed", tableCommandRoute(services, jsonCommand(func() Command { return &AddClientSeedCmd{} })))
+	router.Delete("/{id}/dice-session", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &EndDiceSessionCmd{}, nil
+	}))
+	router.Post("/{id}/rolls/{roll}/reveal", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RevealRollCmd{Roll: chi.URLParam(r, "roll")}, nil
+	}))
+	router.Post("/{id}/bots", tableCommandRoute(services, jsonCommand(func() Command { return &InviteBotCmd{} })))
+	router.Delete("/{id}/bots/{bot}", tableCommandRoute(services, func(r *http.Request) (Command, error) {
+		return &RemoveBotCmd{Bot: chi.URLParam(r, "bot")}, nil
+	}))
 
 	router.Mount("/{id}/subscribe", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		natsConn := app_context.GetNats(ctx)
+		// The socket outlives the timeout of the request, it is canceled once closed.
+		ctx, cancel := context.WithCancel(app_context.Detach(r.Context()))
+		defer cancel()
 		user := app_context.GetAuthUser(ctx)
 		id := chi.URLParam(r, "id")
-		table, err := services.ById(id, ctx)
+		table, err := services.loadTable(id, ctx)
 		if err != nil {
 			_ = render.Render(w, r, lib.ToHttpError(err))
 			return
 		}
-		if table == nil {
-			_ = render.Render(w, r, lib.HttpNotFound(nil))
+		if !table.IsMember(user) {
+			_ = render.Render(w, r, lib.HttpForbidden(fmt.Errorf("only the members of the table can subscribe")))
+			return
+		}
+		if table.ArchivedAt != nil {
+			_ = render.Render(w, r, lib.HttpConflict(fmt.Errorf("table %s is archived", id)))
+			return
+		}
+		sub, err := services.subscribe(table, []string{TableSubject(id)}, ctx)
+		if err != nil {
+			_ = render.Render(w, r, lib.ToHttpError(err))
 			return
 		}
-		// TODO Check if exists
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			// TODO Manage error properly
 			log.Error(err)
+			sub.Close()
 			return
 		}
 		//defer func() { _ = conn.Close() }()
+		replies := make(chan []byte, 16)
+		done := make(chan struct{})
 		go func() {
 			ticker := time.NewTicker(pingPeriod)
 			defer func() {
 				ticker.Stop()
+				sub.Close()
 				_ = conn.Close()
+				services.sendEvent(ctx, table.Id, &PlayerDisconnected{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user})
 			}()
-			messages := make(chan *nats.Msg)
-			sub, err := natsConn.ChanSubscribe(id, messages)
-			if err != nil {
-				// TODO Manage error properly
-				log.Error(err)
-				return
-			}
-			defer func() { _ = sub.Unsubscribe() }()
 			for {
 				select {
-				case message, ok := <-messages:
+				case <-done:
+					return
+				case reply := <-replies:
+					_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+					if err := conn.WriteMessage(websocket.TextMessage, reply); err != nil {
+						return
+					}
+				case message, ok := <-sub.Messages:
 					_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
 					if !ok {
 						// Channel  closed
 						_ = conn.WriteMessage(websocket.CloseMessage, []byte{})
 						return
 					}
+					data, visible := sub.Visible(message)
+					if !visible {
+						continue
+					}
 
 					w, err := conn.NextWriter(websocket.TextMessage)
 					if err != nil {
@@ -146,13 +614,18 @@ func Route(router chi.Router) {
 						log.Error(err)
 						return
 					}
-					_, _ = w.Write(message.Data)
+					_, _ = w.Write(data)
 
 					if err := w.Close(); err != nil {
 						// TODO Manage error properly
 						log.Error(err)
 						return
 					}
+					if sub.Closes(message) {
+						// Kicked, banned, or the table is archived or deleted.
+						_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "closed by "+string(sub.closedBy)))
+						return
+					}
 				case <-ticker.C:
 					_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
 					if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -163,7 +636,10 @@ func Route(router chi.Router) {
 		}()
 
 		// TODO send via service.
-		m, _ := WriteEventJson(&PlayerJoint{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user})
-		_ = natsConn.Publish(id, m)
+		services.sendEvent(ctx, table.Id, &PlayerConnected{EventBase: NewEventBase(table.Id, []string{"*"}, user), Player: user})
+
+		// Read on the request goroutine, the context is canceled once the handler returns.
+		readCommands(services, conn, id, replies, ctx)
+		close(done)
 	}))
 }
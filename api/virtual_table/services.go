@@ -3,12 +3,17 @@ package virtual_table
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/rpg-tools/toolbox-services/api/game_system"
+	"github.com/rpg-tools/toolbox-services/api/user"
 	"github.com/rpg-tools/toolbox-services/app_context"
 	"github.com/rpg-tools/toolbox-services/lib"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
 )
 
 const (
@@ -18,7 +23,8 @@ const (
 
 type tableServices struct{}
 
-func (*tableServices) withMongoTransaction(ctx context.Context, db *mongo.Database, fn func() error) error {
+// withMongoTransaction runs fn in a transaction, its writes must use the context it is given.
+func (*tableServices) withMongoTransaction(ctx context.Context, db *mongo.Database, fn func(sc context.Context) error) error {
 	s, err := db.Client().StartSession()
 	if err != nil {
 		return err
@@ -29,7 +35,7 @@ func (*tableServices) withMongoTransaction(ctx context.Context, db *mongo.Databa
 		return err
 	}
 	return mongo.WithSession(ctx, s, func(sc mongo.SessionContext) error {
-		if err := fn(); err != nil {
+		if err := fn(sc); err != nil {
 			return err
 		}
 		if err := s.CommitTransaction(sc); err != nil {
@@ -41,12 +47,246 @@ func (*tableServices) withMongoTransaction(ctx context.Context, db *mongo.Databa
 
 func (*tableServices) sendEvent(ctx context.Context, table primitive.ObjectID, evt Event) {
 	natsConn := app_context.GetNats(ctx)
-	p, err := json.Marshal(evt)
+	e, err := json.Marshal(evt)
 	if err != nil {
 		// TODO manage error
 		return
 	}
-	_ = natsConn.Publish(table.Hex(), p)
+	p, err := json.Marshal(&streamMessage{AllowUsers: evt.GetAllowUsers(), Event: e})
+	if err != nil {
+		// TODO manage error
+		return
+	}
+	_ = natsConn.Publish(EventSubject(table.Hex(), evt.Kind()), p)
+}
+
+// tableUpdate is an update of a table, with the filters of the array elements it updates.
+type tableUpdate struct {
+	update       bson.M
+	arrayFilters []interface{}
+	// Conditions the table must still match when updated, like a free seat, the commit fails with mismatch otherwise.
+	filter   bson.M
+	mismatch error
+}
+
+// commit journals the event, applies the update on its table and sends the event.
+func (s *tableServices) commit(ctx context.Context, table primitive.ObjectID, evt Event, update bson.M, arrayFilters ...interface{}) error {
+	if update == nil {
+		return s.commitUpdates(ctx, table, evt)
+	}
+	return s.commitUpdates(ctx, table, evt, tableUpdate{update: update, arrayFilters: arrayFilters})
+}
+
+// commitUpdates is commit with several updates applied in order, for the ones which would conflict in a single update,
+// like pulling an element of an array and setting a field of another one.
+func (s *tableServices) commitUpdates(ctx context.Context, table primitive.ObjectID, evt Event, updates ...tableUpdate) error {
+	return s.commitWith(ctx, table, evt, nil, updates...)
+}
+
+// commitWith is commitUpdates with the writes of other collections, like closing a join request, run first in the same
+// transaction: the event is neither journaled nor applied when they fail.
+func (s *tableServices) commitWith(ctx context.Context, table primitive.ObjectID, evt Event, writes func(sc context.Context) error, updates ...tableUpdate) error {
+	db := app_context.GetMongodb(ctx)
+	evtAsMap, err := WriteEvent(evt, "bson")
+	if err != nil {
+		return err
+	}
+	if err := s.withMongoTransaction(ctx, db, func(sc context.Context) error {
+		if writes != nil {
+			if err := writes(sc); err != nil {
+				return err
+			}
+		}
+		if _, err := db.Collection(journalCollectionName).InsertOne(sc, evtAsMap); err != nil {
+			return err
+		}
+		for _, u := range updates {
+			opts := options.Update()
+			if len(u.arrayFilters) > 0 {
+				opts.SetArrayFilters(options.ArrayFilters{Filters: u.arrayFilters})
+			}
+			filter := bson.M{"_id": table}
+			for k, v := range u.filter {
+				filter[k] = v
+			}
+			res, err := db.Collection(collectionName).UpdateOne(sc, filter, u.update, opts)
+			if err != nil {
+				return err
+			}
+			if res.MatchedCount == 0 && u.filter != nil {
+				return u.mismatch
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.sendEvent(ctx, table, evt)
+	return nil
+}
+
+// loadTable reads the table document, without the aggregation of its events. Tables in the trash are not found.
+func (s *tableServices) loadTable(id string, ctx context.Context) (*Table, error) {
+	return s.findTable(id, bson.M{"deletedAt": nil}, ctx)
+}
+
+func (*tableServices) findTable(id string, filters bson.M, ctx context.Context) (*Table, error) {
+	db := app_context.GetMongodb(ctx)
+	bsonId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, lib.HttpBadRequest(err)
+	}
+	filters["_id"] = bsonId
+	res := &Table{}
+	err = db.Collection(collectionName).FindOne(ctx, filters).Decode(res)
+	if err == mongo.ErrNoDocuments {
+		return nil, lib.HttpNotFound(fmt.Errorf("table %s not found", id))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// authorize checks that the role of the user at the table allows the command, with the table settings.
+// Bots are allowed only the commands they were invited for, and no command outside of a table.
+func (*tableServices) authorize(table *Table, kind CommandKind, ctx context.Context) error {
+	user := app_context.GetAuthUser(ctx)
+	if table == nil {
+		if app_context.IsAuthBot(ctx) {
+			return lib.HttpForbidden(fmt.Errorf("bots cannot run %s", kind))
+		}
+		return nil
+	}
+	if app_context.IsAuthBot(ctx) {
+		if bot := table.Bot(user); bot != nil && bot.Allows(kind) && !ownerOnlyKinds[kind] {
+			return nil
+		}
+		return &PermissionDeniedError{Table: table.Id.Hex(), User: user, Command: kind}
+	}
+	role := table.RoleOf(user)
+	if !table.Settings.Allows(role, kind) {
+		return &PermissionDeniedError{Table: table.Id.Hex(), User: user, Role: role, Command: kind}
+	}
+	return nil
+}
+
+// Handle runs a command at a table. Archived tables accept only the commands of their lifecycle.
+func (s *tableServices) Handle(tableId string, cmd Command, ctx context.Context) (Event, error) {
+	var table *Table
+	var err error
+	if _, ok := cmd.(*RestoreTableCmd); ok {
+		// Tables are restored from the trash too.
+		table, err = s.findTable(tableId, bson.M{}, ctx)
+	} else {
+		table, err = s.loadTable(tableId, ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(table, cmd.Kind(), ctx); err != nil {
+		return nil, err
+	}
+	// Archived tables can still be cloned.
+	if table.ArchivedAt != nil && cmd.Kind() != RestoreTableKind && cmd.Kind() != DeleteTableKind && cmd.Kind() != CloneTableKind {
+		return nil, lib.HttpConflict(fmt.Errorf("table %s is archived", tableId))
+	}
+	switch c := cmd.(type) {
+	case *CreateCharacterCmd:
+		return s.CreateCharacter(table, c, ctx)
+	case *UpdateCharacterCmd:
+		return s.UpdateCharacter(table, c, ctx)
+	case *AssignCharacterCmd:
+		return s.AssignCharacter(table, c, ctx)
+	case *RetireCharacterCmd:
+		return s.RetireCharacter(table, c, ctx)
+	case *DeleteCharacterCmd:
+		return s.DeleteCharacter(table, c, ctx)
+	case *SaveMacroCmd:
+		return s.SaveMacro(table, c, ctx)
+	case *DeleteMacroCmd:
+		return s.DeleteMacro(table, c, ctx)
+	case *RunMacroCmd:
+		return s.RunMacro(table, c, ctx)
+	case *CloneTableCmd:
+		return s.CloneTable(table, c, ctx)
+	case *UpdateDiscussionCmd:
+		return s.UpdateDiscussion(table, c, ctx)
+	case *CreateHandoutCmd:
+		return s.CreateHandout(table, c, ctx)
+	case *ShareHandoutCmd:
+		return s.ShareHandout(table, c, ctx)
+	case *DeleteHandoutCmd:
+		return s.DeleteHandout(table, c, ctx)
+	case *ArchiveTableCmd:
+		return s.ArchiveTable(table, c, ctx)
+	case *DeleteTableCmd:
+		return s.DeleteTable(table, c, ctx)
+	case *RestoreTableCmd:
+		return s.RestoreTable(table, c, ctx)
+	case *UpdateSettingsCmd:
+		return s.UpdateSettings(table, c, ctx)
+	case *CreateInviteCmd:
+		return s.CreateInvite(table, c, ctx)
+	case *RevokeInviteCmd:
+		return s.RevokeInvite(table, c, ctx)
+	case *UpdateLobbyCmd:
+		return s.UpdateLobby(table, c, ctx)
+	case *ApproveJoinRequestCmd:
+		return s.ApproveJoinRequest(table, c, ctx)
+	case *RejectJoinRequestCmd:
+		return s.RejectJoinRequest(table, c, ctx)
+	case *TransferOwnershipCmd:
+		return s.TransferOwnership(table, c, ctx)
+	case *AddCoMasterCmd:
+		return s.AddCoMaster(table, c, ctx)
+	case *RemoveCoMasterCmd:
+		return s.RemoveCoMaster(table, c, ctx)
+	case *KickPlayerCmd:
+		return s.KickPlayer(table, c, ctx)
+	case *BanPlayerCmd:
+		return s.BanPlayer(table, c, ctx)
+	case *UnbanPlayerCmd:
+		return s.UnbanPlayer(table, c, ctx)
+	case *MutePlayerCmd:
+		return s.MutePlayer(table, c, ctx)
+	case *SendMessageCmd:
+		return s.SendMessage(table, c, ctx)
+	case *RollDiceCmd:
+		return s.RollDice(table, c, ctx)
+	case *RevealRollCmd:
+		return s.RevealRoll(table, c, ctx)
+	case *StartDiceSessionCmd:
+		return s.StartDiceSession(table, c, ctx)
+	case *AddClientSeedCmd:
+		return s.AddClientSeed(table, c, ctx)
+	case *EndDiceSessionCmd:
+		return s.EndDiceSession(table, c, ctx)
+	case *StartEncounterCmd:
+		return s.StartEncounter(table, c, ctx)
+	case *AddCombatantCmd:
+		return s.AddCombatant(table, c, ctx)
+	case *RemoveCombatantCmd:
+		return s.RemoveCombatant(table, c, ctx)
+	case *NextTurnCmd:
+		return s.NextTurn(table, c, ctx)
+	case *PreviousTurnCmd:
+		return s.PreviousTurn(table, c, ctx)
+	case *DelayTurnCmd:
+		return s.DelayTurn(table, c, ctx)
+	case *ReadyActionCmd:
+		return s.ReadyAction(table, c, ctx)
+	case *ActNowCmd:
+		return s.ActNow(table, c, ctx)
+	case *EndEncounterCmd:
+		return s.EndEncounter(table, c, ctx)
+	case *InviteBotCmd:
+		return s.InviteBot(table, c, ctx)
+	case *RemoveBotCmd:
+		return s.RemoveBot(table, c, ctx)
+	default:
+		return nil, lib.HttpBadRequest(fmt.Errorf("%s cannot be run at a table", cmd.Kind()))
+	}
 }
 
 // Read part
@@ -61,6 +301,7 @@ func (*tableServices) aggregate(filters bson.M, limit int, ctx context.Context)
 		bson.D{{"$match", bson.M{"$or": bson.A{
 			bson.M{"discussions.between": user},
 			bson.M{"discussions.between": "*"},
+			bson.M{"discussions.between": MastersAudience, "$or": bson.A{bson.M{"master": user}, bson.M{"coMasters": user}}},
 		}}}},
 		bson.D{{"$group", bson.M{"_id": "$_id", "doc": bson.M{"$first": "$$ROOT"}, "discussions": bson.M{"$push": "$discussions"}}}},
 		bson.D{{"$replaceRoot", bson.M{"newRoot": bson.M{"$mergeObjects": bson.A{"$doc", bson.M{"discussions": "$discussions"}}}}}},
@@ -68,6 +309,7 @@ func (*tableServices) aggregate(filters bson.M, limit int, ctx context.Context)
 			"from": journalCollectionName,
 			"let": bson.M{
 				"tableId": "$_id",
+				"masters": bson.M{"$concatArrays": bson.A{bson.A{"$master"}, bson.M{"$ifNull": bson.A{"$coMasters", bson.A{}}}}},
 			},
 			"pipeline": bson.A{
 				bson.M{"$match": bson.M{
@@ -77,6 +319,10 @@ func (*tableServices) aggregate(filters bson.M, limit int, ctx context.Context)
 							bson.M{"$or": bson.A{
 								bson.M{"$in": bson.A{"*", "$allowUsers"}},
 								bson.M{"$in": bson.A{user, "$allowUsers"}},
+								bson.M{"$and": bson.A{
+									bson.M{"$in": bson.A{MastersAudience, "$allowUsers"}},
+									bson.M{"$in": bson.A{user, "$$masters"}},
+								}},
 							}},
 						},
 					},},
@@ -104,32 +350,91 @@ func (*tableServices) aggregate(filters bson.M, limit int, ctx context.Context)
 	res := make([]*TableWithEvents, len(all))
 	for idx, item := range all {
 		obj := &TableWithEvents{Table: item.Table, Events: make([]Event, 0)}
+		obj.visibleCharacters(user)
+		obj.visibleHandouts(user)
+		obj.visibleCombatants(user)
 		// Read events
 		for _, evt := range item.Events {
 			e, err := ReadEvent(evt, "bson")
 			if err != nil {
 				return nil, err
 			}
-			obj.Events = append(obj.Events, e)
+			obj.Events = append(obj.Events, visibleEvent(e, obj.IsMaster(user)))
 		}
 		res[idx] = obj
 	}
+	if err = resolveUsers(res, ctx); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
-// TODO Indexation
+func resolveUsers(tables []*TableWithEvents, ctx context.Context) error {
+	ids := make([]string, 0)
+	for _, table := range tables {
+		ids = append(ids, table.Masters()...)
+		ids = append(ids, table.Players...)
+	}
+	profiles, err := user.Profiles(ids, ctx)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		table.Users = make(map[string]user.Profile)
+		for _, id := range append(table.Masters(), table.Players...) {
+			if p, ok := profiles[id]; ok {
+				table.Users[id] = p
+			}
+		}
+	}
+	return nil
+}
+
+// activeFilter matches the tables neither archived nor deleted. Null also matches the missing dates, and the null
+// dates stored before the omitempty tags were honored.
+var activeFilter = bson.M{"archivedAt": nil, "deletedAt": nil}
+
+// membershipFilter matches the tables the user is a member of.
+func membershipFilter(user string) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"master": user},
+		bson.M{"coMasters": user},
+		bson.M{"players": user},
+		bson.M{"spectators": user},
+		bson.M{"bots.id": user},
+	}}
+}
+
 // TODO As stream
-func (s *tableServices) Search(filters interface{}, ctx context.Context) ([]*TableWithEvents, error) {
-	// TODO Filters...
-	return s.aggregate(bson.M{}, -1, ctx)
+// Search returns the tables of the authenticated user.
+func (s *tableServices) Search(query SearchTablesQuery, ctx context.Context) ([]*TableWithEvents, error) {
+	user := app_context.GetAuthUser(ctx)
+	filters := bson.A{membershipFilter(user)}
+	switch {
+	case query.Deleted:
+		filters = append(filters, bson.M{"master": user, "deletedAt": bson.M{"$ne": nil}})
+	case query.Archived:
+		filters = append(filters, bson.M{"archivedAt": bson.M{"$ne": nil}, "deletedAt": nil})
+	default:
+		filters = append(filters, activeFilter)
+	}
+	if query.Templates {
+		filters = append(filters, bson.M{"settings.template": true})
+	}
+	if query.Name != "" {
+		filters = append(filters, bson.M{"name": bson.M{"$regex": regexp.QuoteMeta(query.Name), "$options": "i"}})
+	}
+	return s.aggregate(bson.M{"$and": filters}, -1, ctx)
 }
 
+// ById returns the table with its events, nil when the authenticated user is not a member, as if it did not exist.
 func (s *tableServices) ById(id string, ctx context.Context) (*TableWithEvents, error) {
+	user := app_context.GetAuthUser(ctx)
 	bsonId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
-	res, err := s.aggregate(bson.M{"_id": bsonId}, 1, ctx)
+	res, err := s.aggregate(bson.M{"$and": bson.A{bson.M{"_id": bsonId, "deletedAt": nil}, membershipFilter(user)}}, 1, ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -142,33 +447,55 @@ func (s *tableServices) ById(id string, ctx context.Context) (*TableWithEvents,
 // Commands.
 
 func (s *tableServices) CreateTable(cmd CreateTableCmd, ctx context.Context) (Event, error) {
-	db := app_context.GetMongodb(ctx)
 	user := app_context.GetAuthUser(ctx)
+	if err := s.authorize(nil, cmd.Kind(), ctx); err != nil {
+		return nil, err
+	}
 
-	table := Table{Id: primitive.NewObjectID(), Name: cmd.Name, Master: user, Players: []string{}, Characters: []Character{}, Discussions: []Discussion{
+	if cmd.GameSystem != "" && game_system.ById(cmd.GameSystem) == nil {
+		return nil, lib.HttpBadRequest(fmt.Errorf("unknown game system %s", cmd.GameSystem))
+	}
+
+	table := newTable(cmd.Name, user)
+	table.Settings.GameSystem = cmd.GameSystem
+	table.Discussions = []Discussion{
 		{Id: uuid.New().String(), Name: "General", Persistent: true, Between: []string{"*"}, Messages: []Message{}}, // Channels between all users
-		{Id: uuid.New().String(), Name: "Master", Persistent: true, Between: []string{user}, Messages: []Message{}}, // Master screen channel
-	}}
+		{Id: uuid.New().String(), Name: "Master", Persistent: true, Between: []string{MastersAudience}, Messages: []Message{}}, // Master screen channel
+	}
 	evt := TableCreated{EventBase: NewEventBase(table.Id, []string{"*"}, user), Name: cmd.Name}
-	evtAsMap, err := WriteEvent(&evt, "bson")
-	if err != nil {
+	if err := s.insertTable(table, &evt, ctx); err != nil {
 		return nil, err
 	}
-	tableAsMap, err := lib.AsMap(&table, "bson")
+	return &evt, nil
+}
+
+// newTable returns an empty table owned by the user.
+func newTable(name string, user string) *Table {
+	return &Table{Id: primitive.NewObjectID(), Name: name, Master: user, CoMasters: []string{}, Players: []string{}, Spectators: []string{}, Banned: []string{}, Mutes: []Mute{}, Characters: []Character{}, Bots: []TableBot{}, Discussions: []Discussion{}, Handouts: []Handout{}}
+}
+
+// insertTable stores a new table, its journal starts with the TableCreated event.
+func (s *tableServices) insertTable(table *Table, evt *TableCreated, ctx context.Context) error {
+	db := app_context.GetMongodb(ctx)
+	evtAsMap, err := WriteEvent(evt, "bson")
+	if err != nil {
+		return err
+	}
+	tableAsMap, err := lib.AsMap(table, "bson")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if err := s.withMongoTransaction(ctx, db, func() error {
-		if _, err := db.Collection(journalCollectionName).InsertOne(ctx, evtAsMap); err != nil {
+	if err := s.withMongoTransaction(ctx, db, func(sc context.Context) error {
+		if _, err := db.Collection(journalCollectionName).InsertOne(sc, evtAsMap); err != nil {
 			return err
 		}
-		if _, err := db.Collection(collectionName).InsertOne(ctx, tableAsMap); err != nil {
+		if _, err := db.Collection(collectionName).InsertOne(sc, tableAsMap); err != nil {
 			return err
 		}
 		return nil
 	}); err != nil {
-		return nil, err
+		return err
 	}
-	s.sendEvent(ctx, table.Id, &evt)
-	return &evt, nil
+	s.sendEvent(ctx, table.Id, evt)
+	return nil
 }
@@ -0,0 +1,73 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/rpg-tools/toolbox-services/api/game_system"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+	"sort"
+)
+
+// validatePermissions checks that the overrides are on known commands, which are not reserved to the owner, and give
+// them to known roles.
+func validatePermissions(permissions map[CommandKind][]Role) []lib.FieldError {
+	errs := make([]lib.FieldError, 0)
+	for kind, roles := range permissions {
+		field := fmt.Sprintf("/permissions/%s", kind)
+		if _, ok := commandsSupplierByKind[kind]; !ok {
+			errs = append(errs, lib.FieldError{Field: field, Message: fmt.Sprintf("%s is not a valid command", kind)})
+			continue
+		}
+		if ownerOnlyKinds[kind] {
+			errs = append(errs, lib.FieldError{Field: field, Message: fmt.Sprintf("%s is run only by the owner", kind)})
+			continue
+		}
+		for idx, r := range roles {
+			if r != CoMasterRole && r != PlayerRole && r != SpectatorRole {
+				errs = append(errs, lib.FieldError{Field: fmt.Sprintf("%s/%d", field, idx), Message: fmt.Sprintf("invalid role %s", r)})
+			}
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+func (s *tableServices) UpdateSettings(table *Table, cmd *UpdateSettingsCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	settings := table.Settings
+	permissions := make(map[CommandKind][]Role)
+	for kind, roles := range settings.Permissions {
+		permissions[kind] = roles
+	}
+	if errs := validatePermissions(cmd.Permissions); len(errs) > 0 {
+		return nil, lib.HttpValidationError(errs)
+	}
+	for kind, roles := range cmd.Permissions {
+		if roles == nil {
+			delete(permissions, kind)
+		} else {
+			permissions[kind] = roles
+		}
+	}
+	settings.Permissions = permissions
+	if cmd.Discoverable != nil {
+		settings.Discoverable = *cmd.Discoverable
+	}
+	if cmd.Template != nil {
+		settings.Template = *cmd.Template
+	}
+	if cmd.GameSystem != nil {
+		if *cmd.GameSystem != "" && game_system.ById(*cmd.GameSystem) == nil {
+			return nil, lib.HttpBadRequest(fmt.Errorf("unknown game system %s", *cmd.GameSystem))
+		}
+		settings.GameSystem = *cmd.GameSystem
+	}
+
+	evt := &SettingsUpdated{EventBase: NewEventBase(table.Id, []string{"*"}, by), Settings: settings}
+	if err := s.commit(ctx, table.Id, evt, bson.M{"$set": bson.M{"settings": settings}}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
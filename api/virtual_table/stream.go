@@ -0,0 +1,164 @@
+package virtual_table
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nats-io/nats.go"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"sync"
+)
+
+// streamMessage is published on NATS, with the users allowed to see the event
+// since they are not part of its json representation.
+type streamMessage struct {
+	AllowUsers []string        `json:"allowUsers"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// Events changing the masters of a table, followed by every subscription to resolve MastersAudience.
+var mastersEventKinds = []EventType{OwnershipTransferredType, CoMasterAddedType, CoMasterRemovedType}
+
+// Events which may end a subscription, see Closes.
+var closingEventKinds = []EventType{PlayerKickedType, PlayerBannedType, TableArchivedType, TableDeletedType}
+
+// subscription receives the events of a table, Visible filters the ones the user cannot see.
+type subscription struct {
+	user     string
+	subs     []*nats.Subscription
+	Messages chan *nats.Msg
+
+	mutex    sync.Mutex
+	master   bool
+	closedBy EventType
+}
+
+func (s *tableServices) subscribe(table *Table, subjects []string, ctx context.Context) (*subscription, error) {
+	natsConn := app_context.GetNats(ctx)
+	user := app_context.GetAuthUser(ctx)
+	res := &subscription{user: user, Messages: make(chan *nats.Msg, 64), master: table.IsMaster(user)}
+	for _, subject := range subjects {
+		sub, err := natsConn.ChanSubscribe(subject, res.Messages)
+		if err != nil {
+			res.Close()
+			return nil, err
+		}
+		res.subs = append(res.subs, sub)
+	}
+	for _, kind := range mastersEventKinds {
+		sub, err := natsConn.Subscribe(KindSubject(table.Id.Hex(), kind), res.followMasters)
+		if err != nil {
+			res.Close()
+			return nil, err
+		}
+		res.subs = append(res.subs, sub)
+	}
+	return res, nil
+}
+
+// followMasters updates whether the user is a master of the table.
+func (s *subscription) followMasters(msg *nats.Msg) {
+	m := streamMessage{}
+	if err := json.Unmarshal(msg.Data, &m); err != nil {
+		return
+	}
+	evt, err := ReadEventJson(m.Event)
+	if err != nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch e := evt.(type) {
+	case *OwnershipTransferred:
+		// The previous owner stays co-master.
+		if e.To == s.user {
+			s.master = true
+		}
+	case *CoMasterAdded:
+		if e.Player == s.user {
+			s.master = true
+		}
+	case *CoMasterRemoved:
+		if e.Player == s.user {
+			s.master = false
+		}
+	}
+}
+
+// Visible returns the json of the event of the message, if the user can see it.
+func (s *subscription) Visible(msg *nats.Msg) ([]byte, bool) {
+	m := streamMessage{}
+	if err := json.Unmarshal(msg.Data, &m); err != nil {
+		return nil, false
+	}
+	s.mutex.Lock()
+	master := s.master
+	s.mutex.Unlock()
+	for _, u := range m.AllowUsers {
+		if u == "*" || u == s.user || (u == MastersAudience && master) {
+			if master {
+				return m.Event, true
+			}
+			return maskedJson(m.Event)
+		}
+	}
+	return nil, false
+}
+
+// maskedJson returns the json of the event as seen by the users who are not masters.
+func maskedJson(data json.RawMessage) ([]byte, bool) {
+	evt := struct {
+		Kind EventType `json:"_kind"`
+	}{}
+	if err := json.Unmarshal(data, &evt); err != nil {
+		return nil, false
+	}
+	supplier, ok := eventsSupplierByKind[evt.Kind]
+	if !ok {
+		return data, true
+	}
+	if _, ok := supplier().(maskedEvent); !ok {
+		return data, true
+	}
+	e, err := ReadEventJson(data)
+	if err != nil {
+		return nil, false
+	}
+	res, err := json.Marshal(visibleEvent(e, false))
+	if err != nil {
+		return nil, false
+	}
+	return res, true
+}
+
+// Closes tells whether the event of the message ends the subscription: the user is kicked or banned,
+// or the table is archived or deleted. The kind of the event is then kept in closedBy.
+func (s *subscription) Closes(msg *nats.Msg) bool {
+	m := streamMessage{}
+	if err := json.Unmarshal(msg.Data, &m); err != nil {
+		return false
+	}
+	evt := struct {
+		Kind   EventType `json:"_kind"`
+		Player string    `json:"player"`
+	}{}
+	if err := json.Unmarshal(m.Event, &evt); err != nil {
+		return false
+	}
+	switch evt.Kind {
+	case PlayerKickedType, PlayerBannedType:
+		if evt.Player != s.user {
+			return false
+		}
+	case TableArchivedType, TableDeletedType:
+	default:
+		return false
+	}
+	s.closedBy = evt.Kind
+	return true
+}
+
+func (s *subscription) Close() {
+	for _, sub := range s.subs {
+		_ = sub.Unsubscribe()
+	}
+}
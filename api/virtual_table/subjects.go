@@ -0,0 +1,104 @@
+package virtual_table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Subjects are versioned and hierarchical:
+//
+//	rpg.v1.tables.<table id>.<category>.<kind>
+//
+// so that a consumer can subscribe with wildcards, for example
+// `rpg.v1.tables.*.chat.>` for every chat event of every table.
+const (
+	subjectPrefix = "rpg.v1.tables"
+)
+
+type EventCategory string
+
+const (
+	TableCategory    EventCategory = "table"
+	PresenceCategory EventCategory = "presence"
+	ChatCategory     EventCategory = "chat"
+	DiceCategory     EventCategory = "dice"
+	CombatCategory   EventCategory = "combat"
+)
+
+// eventCategories are the categories a stream can be restricted to.
+var eventCategories = map[EventCategory]bool{
+	TableCategory:    true,
+	PresenceCategory: true,
+	ChatCategory:     true,
+	DiceCategory:     true,
+	CombatCategory:   true,
+}
+
+var eventsCategoryByKind = map[EventType]EventCategory{
+	TableCreatedType:             TableCategory,
+	PlayerJointType:              PresenceCategory,
+	PlayerConnectedType:          PresenceCategory,
+	PlayerDisconnectedType:       PresenceCategory,
+	PlayerWritingMessageType:     ChatCategory,
+	PlayerStopWritingMessageType: ChatCategory,
+	PlayerSentMessageType:        ChatCategory,
+	JoinRequestedType:            PresenceCategory,
+	JoinRequestApprovedType:      PresenceCategory,
+	JoinRequestRejectedType:      PresenceCategory,
+	PlayerKickedType:             PresenceCategory,
+	PlayerBannedType:             PresenceCategory,
+	PlayerUnbannedType:           PresenceCategory,
+	PlayerMutedType:              ChatCategory,
+	DiceRolledType:               DiceCategory,
+	RollRevealedType:             DiceCategory,
+	DiceSessionStartedType:       DiceCategory,
+	ClientSeedAddedType:          DiceCategory,
+	DiceSessionEndedType:         DiceCategory,
+	EncounterStartedType:         CombatCategory,
+	CombatantAddedType:           CombatCategory,
+	CombatantRemovedType:         CombatCategory,
+	TurnChangedType:              CombatCategory,
+	EncounterEndedType:           CombatCategory,
+}
+
+// CategoryOf returns the category of an event kind, TableCategory by default.
+func CategoryOf(kind EventType) EventCategory {
+	if c, ok := eventsCategoryByKind[kind]; ok {
+		return c
+	}
+	return TableCategory
+}
+
+// subjectToken strips the `evt:` prefix of the kind, NATS tokens must not contain dots.
+func subjectToken(kind EventType) string {
+	return strings.ReplaceAll(strings.TrimPrefix(string(kind), "evt:"), ".", "-")
+}
+
+// EventSubject is the subject on which an event of the given table is published.
+func EventSubject(tableId string, kind EventType) string {
+	return fmt.Sprintf("%s.%s.%s.%s", subjectPrefix, tableId, CategoryOf(kind), subjectToken(kind))
+}
+
+// TableSubject matches every event of a table.
+func TableSubject(tableId string) string {
+	return fmt.Sprintf("%s.%s.>", subjectPrefix, tableId)
+}
+
+// CategorySubject matches every event of a category, tableId can be `*` for all tables.
+func CategorySubject(tableId string, category EventCategory) string {
+	return fmt.Sprintf("%s.%s.%s.*", subjectPrefix, tableId, category)
+}
+
+// KindSubject matches every event of a kind, tableId can be `*` for all tables.
+func KindSubject(tableId string, kind EventType) string {
+	return fmt.Sprintf("%s.%s.*.%s", subjectPrefix, tableId, subjectToken(kind))
+}
+
+// SubjectCategory returns the category of the subject of an event.
+func SubjectCategory(subject string) EventCategory {
+	tokens := strings.Split(strings.TrimPrefix(subject, subjectPrefix+"."), ".")
+	if len(tokens) < 2 {
+		return ""
+	}
+	return EventCategory(tokens[1])
+}
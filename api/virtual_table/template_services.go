@@ -0,0 +1,95 @@
+package virtual_table
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rpg-tools/toolbox-services/app_context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Commands.
+
+// CloneTable creates a fresh table from the table: its settings, its lobby, the structure of its shared discussions
+// with the messages of the template ones, its handouts, and optionally its characters. Members, bots and moderation
+// are not copied.
+func (s *tableServices) CloneTable(table *Table, cmd *CloneTableCmd, ctx context.Context) (Event, error) {
+	user := app_context.GetAuthUser(ctx)
+	// Bots do not create tables.
+	if err := s.authorize(nil, CreateTableKind, ctx); err != nil {
+		return nil, err
+	}
+	name := cmd.Name
+	if name == "" {
+		name = table.Name
+	}
+
+	clone := newTable(name, user)
+	clone.Settings = table.Settings
+	clone.Settings.Template = false
+	clone.Settings.Discoverable = false
+	clone.Lobby = table.Lobby
+	for _, d := range table.Discussions {
+		// Discussions between some users are private to them.
+		if !isSharedDiscussion(&d) {
+			continue
+		}
+		messages := []Message{}
+		if d.Template {
+			messages = d.Messages
+		}
+		clone.Discussions = append(clone.Discussions, Discussion{Id: uuid.New().String(), Name: d.Name, Persistent: d.Persistent, Between: d.Between, Messages: messages, Template: d.Template})
+	}
+	for _, h := range table.Handouts {
+		// The players are not copied, the handout is shared only if it was with everyone.
+		shared := []string{}
+		for _, u := range h.SharedWith {
+			if u == "*" {
+				shared = append(shared, u)
+			}
+		}
+		clone.Handouts = append(clone.Handouts, Handout{Id: uuid.New().String(), Name: h.Name, Content: h.Content, SharedWith: shared})
+	}
+	if cmd.Characters {
+		for _, c := range table.Characters {
+			if c.Retired {
+				continue
+			}
+			clone.Characters = append(clone.Characters, Character{Id: uuid.New().String(), Table: clone.Id.Hex(), Name: c.Name, Picture: c.Picture, Hidden: c.Hidden, Sheet: c.Sheet, Derived: c.Derived, Macros: c.Macros})
+		}
+	}
+
+	evt := &TableCreated{EventBase: NewEventBase(clone.Id, []string{"*"}, user), Name: name, ClonedFrom: table.Id.Hex()}
+	if err := s.insertTable(clone, evt, ctx); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
+
+func isSharedDiscussion(d *Discussion) bool {
+	for _, u := range d.Between {
+		if u == "*" || u == MastersAudience {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *tableServices) UpdateDiscussion(table *Table, cmd *UpdateDiscussionCmd, ctx context.Context) (Event, error) {
+	by := app_context.GetAuthUser(ctx)
+	discussion := table.Discussion(cmd.Discussion)
+	if discussion == nil {
+		return nil, lib.HttpNotFound(fmt.Errorf("discussion %s not found", cmd.Discussion))
+	}
+	template := discussion.Template
+	if cmd.Template != nil {
+		template = *cmd.Template
+	}
+	evt := &DiscussionUpdated{EventBase: NewEventBase(table.Id, discussion.Between, by), Discussion: discussion.Id, Template: template}
+	update := bson.M{"$set": bson.M{"discussions.$[d].template": template}}
+	if err := s.commit(ctx, table.Id, evt, update, bson.M{"d.id": discussion.Id}); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -8,21 +8,37 @@ import (
 
 const (
 	AuthTokenContextKey = "ctx:auth:token"
+	AuthUserContextKey  = "ctx:auth:user"
+	AuthBotContextKey   = "ctx:auth:bot"
 )
 
 func GetAuthToken(ctx context.Context) *jwt.Token {
 	r, ok := ctx.Value(AuthTokenContextKey).(*jwt.Token)
 	if !ok {
-		panic(fmt.Sprintf("cannot found key Nats in context"))
+		panic(fmt.Sprintf("cannot found key AuthToken in context"))
 	}
 	return r
 }
 
+// WithAuthUser sets the id of the authenticated user, resolved from the `sub` claim of the token,
+// and whether it is a bot.
+func WithAuthUser(id string, bot bool) func(context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return context.WithValue(context.WithValue(ctx, AuthUserContextKey, id), AuthBotContextKey, bot)
+	}
+}
+
+// GetAuthUser returns the id of the authenticated user.
 func GetAuthUser(ctx context.Context) string {
-	token := GetAuthToken(ctx)
-	claims, ok := token.Claims.(jwt.MapClaims)
+	r, ok := ctx.Value(AuthUserContextKey).(string)
 	if !ok {
-		panic(fmt.Sprintf("cannot read claims of context"))
+		panic(fmt.Sprintf("cannot found key AuthUser in context"))
 	}
-	return claims["email"].(string)
+	return r
+}
+
+// IsAuthBot tells whether the authenticated user is a bot, acting with an api key.
+func IsAuthBot(ctx context.Context) bool {
+	r, _ := ctx.Value(AuthBotContextKey).(bool)
+	return r
 }
@@ -2,7 +2,10 @@ package app_context
 
 import (
 	"context"
+	"github.com/rpg-tools/toolbox-services/lib"
+	"google.golang.org/grpc"
 	"net/http"
+	"time"
 )
 
 type ContextEnrichment func(context.Context) context.Context
@@ -19,3 +22,40 @@ func ContextMiddleware(fns ...ContextEnrichment) func(http.Handler) http.Handler
 		return http.HandlerFunc(fn)
 	}
 }
+
+func ContextUnaryInterceptor(fns ...ContextEnrichment) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for _, fn := range fns {
+			ctx = fn(ctx)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func ContextStreamInterceptor(fns ...ContextEnrichment) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		for _, fn := range fns {
+			ctx = fn(ctx)
+		}
+		return handler(srv, lib.WithStreamContext(stream, ctx))
+	}
+}
+
+// detachedContext keeps the values of its parent, neither its deadline nor its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// Detach returns a context with the values of ctx, the authenticated user and the connections, that outlives it.
+// The long lived sockets run their commands with it, the context of their request times out.
+func Detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
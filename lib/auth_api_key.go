@@ -0,0 +1,49 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ApiKeyPrefix starts every api key, to tell them apart from the JWTs.
+	ApiKeyPrefix = "tbx_"
+	ApiKeyHeader = "X-Api-Key"
+)
+
+// ApiKeyValidator returns the subject owning the api key, or an error when the key is unknown or revoked.
+type ApiKeyValidator func(key string, ctx context.Context) (string, error)
+
+// apiKeyFromAuthorization returns the api key given as bearer token, if any.
+func apiKeyFromAuthorization(authorization string) (string, bool) {
+	parts := strings.SplitN(authorization, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" || !strings.HasPrefix(parts[1], ApiKeyPrefix) {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// apiKeyToken validates the api key and returns a token with the `sub` claim of its owner,
+// so that the api keys are seen as the JWTs by the rest of the application.
+func apiKeyToken(key string, validator ApiKeyValidator, ctx context.Context) (*jwt.Token, error) {
+	if validator == nil {
+		return nil, HttpUnauthorized(fmt.Errorf("api keys are not accepted"))
+	}
+	subject, err := validator(key, ctx)
+	if err != nil {
+		return nil, HttpUnauthorized(err)
+	}
+	// The raw value identifies the token in the caches, never keep the key itself.
+	raw := ApiKeyPrefix + subject
+	return &jwt.Token{Raw: raw, Valid: true, Header: map[string]interface{}{}, Claims: jwt.MapClaims{"sub": subject}}, nil
+}
+
+func apiKeyFromRequest(r *http.Request) (string, bool) {
+	if key := r.Header.Get(ApiKeyHeader); key != "" {
+		return key, true
+	}
+	return apiKeyFromAuthorization(r.Header.Get("Authorization"))
+}
@@ -0,0 +1,65 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// authenticateGrpc reads the bearer token of the `authorization` metadata, as the http middleware does with the header.
+func authenticateGrpc(ctx context.Context, keyGetter jwt.Keyfunc, apiKeys ApiKeyValidator, contextKey string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if keys := md.Get(ApiKeyHeader); len(keys) > 0 {
+		values = []string{"Bearer " + keys[0]}
+	}
+	if len(values) > 0 {
+		if key, ok := apiKeyFromAuthorization(values[0]); ok {
+			token, err := apiKeyToken(key, apiKeys, ctx)
+			if err != nil {
+				return nil, ToGrpcError(err)
+			}
+			return context.WithValue(ctx, contextKey, token), nil
+		}
+	}
+	if len(values) == 0 {
+		return nil, ToGrpcError(HttpUnauthorized(fmt.Errorf("required authorization token not found")))
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, ToGrpcError(HttpUnauthorized(fmt.Errorf("authorization header format must be Bearer {token}")))
+	}
+	token, err := jwt.Parse(parts[1], keyGetter)
+	if err != nil {
+		return nil, ToGrpcError(HttpUnauthorized(err))
+	}
+	if !token.Valid {
+		return nil, ToGrpcError(HttpUnauthorized(fmt.Errorf("token is invalid")))
+	}
+	return context.WithValue(ctx, contextKey, token), nil
+}
+
+func AuthUnaryInterceptor(provider AuthProvider, apiKeys ApiKeyValidator, contextKey string) grpc.UnaryServerInterceptor {
+	keyGetter := provider.ValidationKeyGetter()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticateGrpc(ctx, keyGetter, apiKeys, contextKey)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamInterceptor(provider AuthProvider, apiKeys ApiKeyValidator, contextKey string) grpc.StreamServerInterceptor {
+	keyGetter := provider.ValidationKeyGetter()
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateGrpc(stream.Context(), keyGetter, apiKeys, contextKey)
+		if err != nil {
+			return err
+		}
+		return handler(srv, WithStreamContext(stream, ctx))
+	}
+}
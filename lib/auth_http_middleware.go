@@ -1,12 +1,15 @@
 package lib
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"fmt"
 	jwtmiddleware "github.com/auth0/go-jwt-middleware"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/go-chi/render"
 	"net/http"
+	"strings"
 )
 
 type Reponse struct {
@@ -17,81 +20,96 @@ type Response struct {
 	Message string `json:"message"`
 }
 
-type Jwks struct {
-	Keys []JSONWebKeys `json:"keys"`
+// AuthProvider gives the key used to validate the token of a request.
+type AuthProvider interface {
+	ValidationKeyGetter() jwt.Keyfunc
 }
 
-type JSONWebKeys struct {
-	Kty string   `json:"kty"`
-	Kid string   `json:"kid"`
-	Use string   `json:"use"`
-	N   string   `json:"n"`
-	E   string   `json:"e"`
-	X5c []string `json:"x5c"`
+type oidcAuthProvider struct {
+	keyGetter jwt.Keyfunc
 }
 
-func getPemCert(token *jwt.Token) (string, error) {
-	cert := ""
-	resp, err := http.Get("https://dohrm.eu.auth0.com/.well-known/jwks.json")
+func (p *oidcAuthProvider) ValidationKeyGetter() jwt.Keyfunc { return p.keyGetter }
 
-	if err != nil {
-		return cert, err
-	}
-	defer func() { _ = resp.Body.Close() }()
+// NewOidcAuthProvider validates the tokens signed by the keys of an OIDC issuer.
+func NewOidcAuthProvider(config AuthConfig) AuthProvider {
+	return &oidcAuthProvider{keyGetter: authValidationKeyGetter(config)}
+}
 
-	var jwks = Jwks{}
-	err = json.NewDecoder(resp.Body).Decode(&jwks)
+type AuthConfig struct {
+	// Expected `iss` claim, not checked when empty.
+	Issuer string
+	// Expected `aud` claim, not checked when empty.
+	Audience string
+	// Url of the key set, `<Issuer>.well-known/jwks.json` when empty.
+	JwksUrl string
+}
 
-	if err != nil {
-		return cert, err
+func (c AuthConfig) jwksUrl() string {
+	if c.JwksUrl != "" {
+		return c.JwksUrl
 	}
+	return strings.TrimSuffix(c.Issuer, "/") + "/.well-known/jwks.json"
+}
 
-	for k, _ := range jwks.Keys {
-		if token.Header["kid"] == jwks.Keys[k].Kid {
-			cert = "-----BEGIN CERTIFICATE-----\n" + jwks.Keys[k].X5c[0] + "\n-----END CERTIFICATE-----"
+func authValidationKeyGetter(config AuthConfig) jwt.Keyfunc {
+	jwks := NewJwksCache(config.jwksUrl())
+	return func(token *jwt.Token) (interface{}, error) {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return nil, HttpUnauthorized(fmt.Errorf("invalid claims"))
+		}
+		if config.Audience != "" && !claims.VerifyAudience(config.Audience, true) {
+			return nil, HttpUnauthorized(fmt.Errorf("invalid audience"))
+		}
+		// Verify 'iss' claim
+		if config.Issuer != "" && !claims.VerifyIssuer(config.Issuer, true) {
+			return nil, HttpUnauthorized(fmt.Errorf("invalid issuer"))
 		}
-	}
 
-	if cert == "" {
-		err := HttpUnauthorized(fmt.Errorf("unable to find appropriate key"))
-		return cert, err
+		kid, _ := token.Header["kid"].(string)
+		key, err := jwks.Key(kid)
+		if err != nil {
+			return nil, err
+		}
+		// Never let the token choose another algorithm than the one of the key.
+		switch key.(type) {
+		case *rsa.PublicKey:
+			if token.Method != jwt.SigningMethodRS256 {
+				return nil, HttpUnauthorized(fmt.Errorf("unexpected signing method %s", token.Method.Alg()))
+			}
+		case *ecdsa.PublicKey:
+			if token.Method != jwt.SigningMethodES256 {
+				return nil, HttpUnauthorized(fmt.Errorf("unexpected signing method %s", token.Method.Alg()))
+			}
+		}
+		return key, nil
 	}
-
-	return cert, nil
 }
 
-func AuthHttpMiddleware(auth0Client string, auth0Secret string, contextKey string) func(http.Handler) http.Handler {
+// AuthHttpMiddleware validates the JWT of the request, or its api key when apiKeys is given.
+func AuthHttpMiddleware(provider AuthProvider, apiKeys ApiKeyValidator, contextKey string) func(http.Handler) http.Handler {
 	res := jwtmiddleware.New(jwtmiddleware.Options{
 		UserProperty: contextKey,
 		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err string) {
 			_ = render.Render(w, r, HttpUnauthorized(fmt.Errorf(err)))
 		},
-		ValidationKeyGetter: func(token *jwt.Token) (interface{}, error) {
-			aud := auth0Client
-			checkAud := token.Claims.(jwt.MapClaims).VerifyAudience(aud, false)
-			if !checkAud {
-				return token, HttpUnauthorized(fmt.Errorf("invalid audience"))
-			}
-			// Verify 'iss' claim
-			iss := "https://dohrm.eu.auth0.com/"
-			checkIss := token.Claims.(jwt.MapClaims).VerifyIssuer(iss, false)
-			if !checkIss {
-				return token, HttpUnauthorized(fmt.Errorf("invalid issuer"))
-			}
-
-			cert, err := getPemCert(token)
-			if err != nil {
-				return nil, err
+		ValidationKeyGetter: provider.ValidationKeyGetter(),
+	})
+	return func(next http.Handler) http.Handler {
+		jwtHandler := res.Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := apiKeyFromRequest(r)
+			if !ok {
+				jwtHandler.ServeHTTP(w, r)
+				return
 			}
-
-			result, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cert))
+			token, err := apiKeyToken(key, apiKeys, r.Context())
 			if err != nil {
-				return nil, HttpUnauthorized(err)
+				_ = render.Render(w, r, ToHttpError(err))
+				return
 			}
-			return result, nil
-		},
-	})
-	return func(next http.Handler) http.Handler {
-		return res.Handler(next)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey, token)))
+		})
 	}
 }
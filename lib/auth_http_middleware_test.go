@@ -0,0 +1,111 @@
+package lib
+
+import (
+	"github.com/dgrijalva/jwt-go"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://issuer.test/"
+	testAudience = "toolbox"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user",
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	res, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestAuthValidationKeyGetter(t *testing.T) {
+	rsaKey := newRsaKey(t)
+	ecKey := newEcKey(t)
+	server := newJwksServer(t, rsaJwk("rsa", rsaKey), ecJwk("ec", ecKey), x5cJwk(t, "x5c", rsaKey))
+	keyGetter := authValidationKeyGetter(AuthConfig{Issuer: testIssuer, Audience: testAudience, JwksUrl: server.URL})
+
+	otherIssuer := testClaims()
+	otherIssuer["iss"] = "https://other.test/"
+	otherAudience := testClaims()
+	otherAudience["aud"] = "other"
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"rsa", signToken(t, jwt.SigningMethodRS256, "rsa", testClaims(), rsaKey), true},
+		{"ec", signToken(t, jwt.SigningMethodES256, "ec", testClaims(), ecKey), true},
+		{"x5c", signToken(t, jwt.SigningMethodRS256, "x5c", testClaims(), rsaKey), true},
+		{"unknown kid", signToken(t, jwt.SigningMethodRS256, "unknown", testClaims(), rsaKey), false},
+		{"other key", signToken(t, jwt.SigningMethodRS256, "rsa", testClaims(), newRsaKey(t)), false},
+		// The public key used as a HS256 secret.
+		{"hs256 on rsa key", signToken(t, jwt.SigningMethodHS256, "rsa", testClaims(), rsaKey.N.Bytes()), false},
+		{"rs256 on ec key", signToken(t, jwt.SigningMethodRS256, "ec", testClaims(), rsaKey), false},
+		{"issuer mismatch", signToken(t, jwt.SigningMethodRS256, "rsa", otherIssuer, rsaKey), false},
+		{"audience mismatch", signToken(t, jwt.SigningMethodRS256, "rsa", otherAudience, rsaKey), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			token, err := jwt.Parse(test.token, keyGetter)
+			if test.valid && (err != nil || !token.Valid) {
+				t.Errorf("expected a valid token, got %v", err)
+			}
+			if !test.valid && err == nil && token.Valid {
+				t.Error("expected an invalid token")
+			}
+		})
+	}
+}
+
+func TestAuthHttpMiddleware(t *testing.T) {
+	rsaKey := newRsaKey(t)
+	server := newJwksServer(t, rsaJwk("rsa", rsaKey))
+	provider := NewOidcAuthProvider(AuthConfig{Issuer: testIssuer, Audience: testAudience, JwksUrl: server.URL})
+	handler := AuthHttpMiddleware(provider, nil, "token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value("token").(*jwt.Token); !ok {
+			t.Error("the token must be set in the context")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	otherIssuer := testClaims()
+	otherIssuer["iss"] = "https://other.test/"
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"valid", signToken(t, jwt.SigningMethodRS256, "rsa", testClaims(), rsaKey), http.StatusNoContent},
+		{"missing", "", http.StatusUnauthorized},
+		{"hs256 on rsa key", signToken(t, jwt.SigningMethodHS256, "rsa", testClaims(), rsaKey.N.Bytes()), http.StatusUnauthorized},
+		{"issuer mismatch", signToken(t, jwt.SigningMethodRS256, "rsa", otherIssuer, rsaKey), http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.token != "" {
+				r.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, w.Code)
+			}
+		})
+	}
+}
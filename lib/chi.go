@@ -2,6 +2,7 @@ package lib
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/go-chi/render"
 	"net/http"
 )
@@ -59,9 +60,16 @@ type HttpResponseError struct {
 	Err            error `json:"-"`
 	HTTPStatusCode int   `json:"-"`
 
-	StatusText string `json:"status"`
-	AppCode    int64  `json:"code,omitempty"`
-	ErrorText  string `json:"error,omitempty"`
+	StatusText string       `json:"status"`
+	AppCode    int64        `json:"code,omitempty"`
+	ErrorText  string       `json:"error,omitempty"`
+	Fields     []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError tells why a field of a document is invalid, the field is a json pointer like `/abilities/str`.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e *HttpResponseError) Error() string { return e.Err.Error() }
@@ -102,6 +110,39 @@ func HttpUnauthorized(err error) HttpError {
 	}
 }
 
+func HttpForbidden(err error) HttpError {
+	return &HttpResponseError{
+		Err:            err,
+		HTTPStatusCode: http.StatusForbidden,
+		StatusText:     http.StatusText(http.StatusForbidden),
+		ErrorText:      toErrorString(err),
+	}
+}
+
+func HttpConflict(err error) HttpError {
+	return &HttpResponseError{
+		Err:            err,
+		HTTPStatusCode: http.StatusConflict,
+		StatusText:     http.StatusText(http.StatusConflict),
+		ErrorText:      toErrorString(err),
+	}
+}
+
+// HttpValidationError is returned when a document does not match its schema, with the error of each field.
+func HttpValidationError(fields []FieldError) HttpError {
+	err := fmt.Errorf("%d invalid fields", len(fields))
+	if len(fields) == 1 {
+		err = fmt.Errorf("%s: %s", fields[0].Field, fields[0].Message)
+	}
+	return &HttpResponseError{
+		Err:            err,
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+		StatusText:     http.StatusText(http.StatusUnprocessableEntity),
+		ErrorText:      err.Error(),
+		Fields:         fields,
+	}
+}
+
 func HttpRenderError(err error) HttpError {
 	return &HttpResponseError{
 		Err:            err,
@@ -111,9 +152,20 @@ func HttpRenderError(err error) HttpError {
 	}
 }
 
+// StatusError is an error knowing its http status.
+type StatusError interface {
+	error
+	HttpStatus() int
+}
+
 func ToHttpError(err error) HttpError {
-	// TODO manage properly this status in function of the error.
+	if e, ok := err.(HttpError); ok {
+		return e
+	}
 	status := http.StatusInternalServerError
+	if e, ok := err.(StatusError); ok {
+		status = e.HttpStatus()
+	}
 	return &HttpResponseError{
 		Err:            err,
 		HTTPStatusCode: status,
@@ -0,0 +1,44 @@
+package lib
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context { return s.ctx }
+
+// WithStreamContext replaces the context of a server stream.
+func WithStreamContext(stream grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &contextServerStream{ServerStream: stream, ctx: ctx}
+}
+
+var grpcCodesByHttpStatus = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+}
+
+// ToGrpcError converts an error, eventually an HttpError, to a grpc status error.
+func ToGrpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := ToHttpError(err).(*HttpResponseError); ok {
+		if code, ok := grpcCodesByHttpStatus[e.HTTPStatusCode]; ok {
+			return status.Error(code, e.ErrorText)
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
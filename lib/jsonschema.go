@@ -0,0 +1,192 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// JsonSchema is the subset of JSON Schema used to describe documents: types, nested properties and items,
+// enums and bounds. Documents are validated as decoded from json.
+type JsonSchema struct {
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JsonSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+}
+
+// Validate returns the errors of the fields of the document, none when it is valid.
+func (s *JsonSchema) Validate(doc interface{}) []FieldError {
+	errs := make([]FieldError, 0)
+	s.validate("", doc, &errs)
+	return errs
+}
+
+// Defaults returns the document made of the default values of the schema, objects are created with
+// the defaults of their properties.
+func (s *JsonSchema) Defaults() interface{} {
+	if s.Default != nil {
+		return s.Default
+	}
+	if s.Type != "object" {
+		return nil
+	}
+	res := make(map[string]interface{})
+	for name, property := range s.Properties {
+		if value := property.Defaults(); value != nil {
+			res[name] = value
+		}
+	}
+	return res
+}
+
+func (s *JsonSchema) validate(path string, value interface{}, errs *[]FieldError) {
+	field := path
+	if field == "" {
+		field = "/"
+	}
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Type != "" && !hasJsonType(value, s.Type) {
+		fail("must be of type %s", s.Type)
+		return
+	}
+	if len(s.Enum) > 0 && !inJsonEnum(value, s.Enum) {
+		fail("must be one of %v", s.Enum)
+	}
+	switch v := value.(type) {
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && len([]rune(v)) > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			if matched, err := regexp.MatchString(s.Pattern, v); err != nil || !matched {
+				fail("must match %s", s.Pattern)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, FieldError{Field: path + "/" + escapeJsonPointer(name), Message: "is required"})
+			}
+		}
+		// Sorted, for the errors to be stable.
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, FieldError{Field: path + "/" + escapeJsonPointer(name), Message: "is not allowed"})
+				}
+				continue
+			}
+			property.validate(path+"/"+escapeJsonPointer(name), v[name], errs)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for idx, item := range v {
+				s.Items.validate(fmt.Sprintf("%s/%d", path, idx), item, errs)
+			}
+		}
+	default:
+		if n, ok := jsonNumber(value); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				fail("must be greater than or equal to %v", *s.Minimum)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				fail("must be less than or equal to %v", *s.Maximum)
+			}
+		}
+	}
+}
+
+func hasJsonType(value interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := jsonNumber(value)
+		return ok
+	case "integer":
+		n, ok := jsonNumber(value)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	default:
+		return false
+	}
+}
+
+func jsonNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func inJsonEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+		if a, ok := jsonNumber(e); ok {
+			if b, ok := jsonNumber(value); ok && a == b {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func escapeJsonPointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,211 @@
+package lib
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Minimum delay between two downloads of the key set triggered by an unknown kid.
+	defaultJwksMinRefreshInterval = time.Minute
+	// Keys are downloaded again after this delay, to forget the revoked ones.
+	defaultJwksTtl = 24 * time.Hour
+)
+
+type Jwks struct {
+	Keys []JSONWebKeys `json:"keys"`
+}
+
+type JSONWebKeys struct {
+	Kty string   `json:"kty"`
+	Kid string   `json:"kid"`
+	Use string   `json:"use"`
+	Alg string   `json:"alg"`
+	N   string   `json:"n"`
+	E   string   `json:"e"`
+	Crv string   `json:"crv"`
+	X   string   `json:"x"`
+	Y   string   `json:"y"`
+	X5c []string `json:"x5c"`
+}
+
+// PublicKey returns the *rsa.PublicKey or *ecdsa.PublicKey described by the key,
+// from its x5c certificate chain when given, from its parameters otherwise.
+func (k *JSONWebKeys) PublicKey() (interface{}, error) {
+	if len(k.X5c) > 0 {
+		der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+		if err != nil {
+			return nil, err
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		switch cert.PublicKey.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+			return cert.PublicKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported certificate key type for kid %s", k.Kid)
+		}
+	}
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJwkInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJwkInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s for kid %s", k.Crv, k.Kid)
+		}
+		x, err := decodeJwkInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJwkInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("invalid point for kid %s", k.Kid)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s for kid %s", k.Kty, k.Kid)
+	}
+}
+
+func decodeJwkInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing key parameter")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// JwksCache keeps the keys of a JWKS endpoint by kid.
+type JwksCache struct {
+	url                string
+	client             *http.Client
+	minRefreshInterval time.Duration
+	ttl                time.Duration
+
+	mutex       sync.Mutex
+	keys        map[string]interface{}
+	lastRefresh time.Time
+	// Closed once the download in progress is done, nil when there is none.
+	refreshing chan struct{}
+}
+
+func NewJwksCache(url string) *JwksCache {
+	return &JwksCache{
+		url:                url,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		minRefreshInterval: defaultJwksMinRefreshInterval,
+		ttl:                defaultJwksTtl,
+		keys:               map[string]interface{}{},
+	}
+}
+
+// fetch downloads the usable keys of the key set.
+func (c *JwksCache) fetch() (map[string]interface{}, error) {
+	resp, err := c.client.Get(c.url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download jwks, status %d", resp.StatusCode)
+	}
+
+	var jwks = Jwks{}
+	if err = json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.PublicKey()
+		if err != nil {
+			// Ignore the keys we cannot use, the other ones stay valid.
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+// refresh downloads the key set again. It is called with the mutex locked, which is released during the download.
+func (c *JwksCache) refresh() error {
+	c.lastRefresh = time.Now()
+	refreshing := make(chan struct{})
+	c.refreshing = refreshing
+	c.mutex.Unlock()
+	keys, err := c.fetch()
+	c.mutex.Lock()
+	c.refreshing = nil
+	close(refreshing)
+	if err != nil {
+		return err
+	}
+	c.keys = keys
+	return nil
+}
+
+// Key returns the public key of the kid. The key set is downloaded again when the kid is unknown,
+// at most once per refresh interval.
+func (c *JwksCache) Key(kid string) (interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expired := time.Since(c.lastRefresh) > c.ttl
+	if key, ok := c.keys[kid]; ok && !expired {
+		return key, nil
+	}
+	if refreshing := c.refreshing; refreshing != nil {
+		// Wait for the download in progress rather than starting another one.
+		c.mutex.Unlock()
+		<-refreshing
+		c.mutex.Lock()
+	} else if expired || time.Since(c.lastRefresh) > c.minRefreshInterval {
+		if err := c.refresh(); err != nil {
+			// Keep the stale key while the endpoint is down.
+			if key, ok := c.keys[kid]; ok {
+				return key, nil
+			}
+			return nil, HttpUnauthorized(err)
+		}
+	}
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, HttpUnauthorized(fmt.Errorf("unable to find appropriate key"))
+}
@@ -0,0 +1,242 @@
+package lib
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jwksServer serves a key set which can be changed during the test, and counts its downloads.
+type jwksServer struct {
+	*httptest.Server
+	mutex     sync.Mutex
+	keys      []JSONWebKeys
+	downloads int
+}
+
+func newJwksServer(t *testing.T, keys ...JSONWebKeys) *jwksServer {
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.downloads++
+		_ = json.NewEncoder(w).Encode(Jwks{Keys: s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(keys ...JSONWebKeys) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) count() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.downloads
+}
+
+func encodeJwkInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func newRsaKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newEcKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func rsaJwk(kid string, key *rsa.PrivateKey) JSONWebKeys {
+	return JSONWebKeys{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: "RS256",
+		N:   encodeJwkInt(key.N),
+		E:   encodeJwkInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJwk(kid string, key *ecdsa.PrivateKey) JSONWebKeys {
+	return JSONWebKeys{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Alg: "ES256",
+		Crv: "P-256",
+		X:   encodeJwkInt(key.X),
+		Y:   encodeJwkInt(key.Y),
+	}
+}
+
+func x5cJwk(t *testing.T, kid string, key *rsa.PrivateKey) JSONWebKeys {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return JSONWebKeys{Kty: "RSA", Kid: kid, Use: "sig", X5c: []string{base64.StdEncoding.EncodeToString(der)}}
+}
+
+func TestJSONWebKeysPublicKey(t *testing.T) {
+	rsaKey := newRsaKey(t)
+	ecKey := newEcKey(t)
+
+	jwk := rsaJwk("rsa", rsaKey)
+	key, err := jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, ok := key.(*rsa.PublicKey); !ok || pub.N.Cmp(rsaKey.N) != 0 || pub.E != rsaKey.E {
+		t.Errorf("unexpected rsa key %v", key)
+	}
+
+	jwk = ecJwk("ec", ecKey)
+	key, err = jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, ok := key.(*ecdsa.PublicKey); !ok || pub.X.Cmp(ecKey.X) != 0 || pub.Y.Cmp(ecKey.Y) != 0 || pub.Curve != elliptic.P256() {
+		t.Errorf("unexpected ec key %v", key)
+	}
+
+	jwk = x5cJwk(t, "x5c", rsaKey)
+	key, err = jwk.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub, ok := key.(*rsa.PublicKey); !ok || pub.N.Cmp(rsaKey.N) != 0 {
+		t.Errorf("unexpected x5c key %v", key)
+	}
+
+	invalid := ecJwk("invalid", ecKey)
+	invalid.Y = invalid.X
+	if _, err := invalid.PublicKey(); err == nil {
+		t.Error("a point out of the curve must be rejected")
+	}
+	if _, err := (&JSONWebKeys{Kty: "oct", Kid: "oct"}).PublicKey(); err == nil {
+		t.Error("a symmetric key must be rejected")
+	}
+}
+
+func TestJwksCacheKey(t *testing.T) {
+	rsaKey := newRsaKey(t)
+	server := newJwksServer(t, rsaJwk("first", rsaKey), ecJwk("second", newEcKey(t)))
+	cache := NewJwksCache(server.URL)
+
+	for i := 0; i < 3; i++ {
+		key, err := cache.Key("first")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pub, ok := key.(*rsa.PublicKey); !ok || pub.N.Cmp(rsaKey.N) != 0 {
+			t.Fatalf("unexpected key %v", key)
+		}
+	}
+	if _, err := cache.Key("second"); err != nil {
+		t.Fatal(err)
+	}
+	if count := server.count(); count != 1 {
+		t.Errorf("the key set must be downloaded once, got %d downloads", count)
+	}
+}
+
+func TestJwksCacheRefreshOnUnknownKid(t *testing.T) {
+	server := newJwksServer(t, rsaJwk("first", newRsaKey(t)))
+	cache := NewJwksCache(server.URL)
+	if _, err := cache.Key("first"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotated keys are found once the refresh interval is over.
+	server.setKeys(rsaJwk("first", newRsaKey(t)), rsaJwk("rotated", newRsaKey(t)))
+	if _, err := cache.Key("rotated"); err == nil {
+		t.Error("the key set must not be downloaded again before the refresh interval")
+	}
+	if count := server.count(); count != 1 {
+		t.Errorf("expected 1 download, got %d", count)
+	}
+
+	cache.minRefreshInterval = 0
+	if _, err := cache.Key("rotated"); err != nil {
+		t.Fatal(err)
+	}
+	if count := server.count(); count != 2 {
+		t.Errorf("expected 2 downloads, got %d", count)
+	}
+}
+
+func TestJwksCacheRateLimit(t *testing.T) {
+	server := newJwksServer(t, rsaJwk("first", newRsaKey(t)))
+	cache := NewJwksCache(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cache.Key("unknown")
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 5; i++ {
+		if _, err := cache.Key("unknown"); err == nil {
+			t.Fatal("an unknown kid must be rejected")
+		}
+	}
+	if count := server.count(); count != 1 {
+		t.Errorf("unknown kids must download the key set at most once per interval, got %d downloads", count)
+	}
+}
+
+func TestJwksCacheExpiredKeys(t *testing.T) {
+	server := newJwksServer(t, rsaJwk("first", newRsaKey(t)))
+	cache := NewJwksCache(server.URL)
+	if _, err := cache.Key("first"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The revoked keys are forgotten once the key set expires.
+	cache.ttl = 0
+	server.setKeys(rsaJwk("second", newRsaKey(t)))
+	if _, err := cache.Key("first"); err == nil {
+		t.Error("a revoked key must be rejected once the key set expires")
+	}
+
+	// The stale keys are kept while the endpoint is down.
+	if _, err := cache.Key("second"); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	if _, err := cache.Key("second"); err != nil {
+		t.Errorf("the stale key must be kept while the endpoint is down: %v", err)
+	}
+}
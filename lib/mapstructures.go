@@ -1,8 +1,11 @@
 package lib
 
 import (
+	"fmt"
 	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -14,6 +17,10 @@ func mapStructureToTimeHookFunc() mapstructure.DecodeHookFunc {
 		if t != reflect.TypeOf(time.Time{}) {
 			return data, nil
 		}
+		// Dates read from BSON.
+		if d, ok := data.(primitive.DateTime); ok {
+			return time.Unix(int64(d)/1000, int64(d)%1000*int64(time.Millisecond)).UTC(), nil
+		}
 
 		switch f.Kind() {
 		case reflect.String:
@@ -28,12 +35,24 @@ func mapStructureToTimeHookFunc() mapstructure.DecodeHookFunc {
 	}
 }
 
+func mapStructureToObjectIdHookFunc() mapstructure.DecodeHookFunc {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data interface{}) (interface{}, error) {
+		if t != reflect.TypeOf(primitive.ObjectID{}) || f.Kind() != reflect.String {
+			return data, nil
+		}
+		return primitive.ObjectIDFromHex(data.(string))
+	}
+}
+
 func mapStructureDecode(input interface{}, result interface{}, tagName string) error {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		TagName: tagName,
-		Squash:  true,
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapStructureToTimeHookFunc(),
+			mapStructureToObjectIdHookFunc(),
 		),
 		Result: result,
 	})
@@ -47,15 +66,108 @@ func mapStructureDecode(input interface{}, result interface{}, tagName string) e
 	return err
 }
 
+// FromMap fills a struct from a map keyed by the names of the tags, the reverse of AsMap.
 func FromMap(input map[string]interface{}, result interface{}, tagName string) error {
-	return mapStructureDecode(input, result, tagName)
+	value := reflect.Indirect(reflect.ValueOf(result))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot fill %s from a map", value.Type())
+	}
+	return structFromMap(input, value, tagName)
+}
+
+func structFromMap(input map[string]interface{}, value reflect.Value, tagName string) error {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get(tagName), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := structFromMap(input, value.Field(i), tagName); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		data, ok := input[name]
+		if !ok {
+			continue
+		}
+		if err := mapStructureDecode(data, value.Field(i).Addr().Interface(), tagName); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
 }
 
+// AsMap converts a struct to a map keyed by the names of the tags. Only the embedded structs are squashed,
+// the other fields are kept as they are to be marshaled with their own format, like the nested structs and the times.
 func AsMap(input interface{}, tagName string) (map[string]interface{}, error) {
-	result := make(map[string]interface{})
-	err := mapStructureDecode(input, &result, tagName)
-	if err != nil {
-		return nil, err
+	value := reflect.Indirect(reflect.ValueOf(input))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert %s to a map", value.Type())
 	}
+	result := make(map[string]interface{})
+	structAsMap(value, tagName, result)
 	return result, nil
 }
+
+func structAsMap(value reflect.Value, tagName string, result map[string]interface{}) {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get(tagName), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			structAsMap(value.Field(i), tagName, result)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if hasOption(tag[1:], "omitempty") && isEmptyValue(value.Field(i)) {
+			continue
+		}
+		result[name] = value.Field(i).Interface()
+	}
+}
+
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue tells whether an `omitempty` field is left out, as encoding/json does.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
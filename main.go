@@ -8,21 +8,28 @@ import (
 	"fmt"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/gorilla/websocket"
 	"github.com/nats-io/nats.go"
 	"github.com/rpg-tools/toolbox-services/admin"
+	"github.com/rpg-tools/toolbox-services/api/dice"
+	"github.com/rpg-tools/toolbox-services/api/game_system"
+	"github.com/rpg-tools/toolbox-services/api/user"
 	"github.com/rpg-tools/toolbox-services/api/virtual_table"
 	"github.com/rpg-tools/toolbox-services/app_context"
 	"github.com/rpg-tools/toolbox-services/lib"
+	"github.com/rpg-tools/toolbox-services/migrations"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"google.golang.org/grpc"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
 )
 
-func Router(auth0Client string, auth0Secret string, enrichment ...app_context.ContextEnrichment) chi.Router {
+func Router(authProvider lib.AuthProvider, enrichment ...app_context.ContextEnrichment) chi.Router {
 	mux := chi.NewMux()
 
 	// A good base middleware stack
@@ -33,23 +40,76 @@ func Router(auth0Client string, auth0Secret string, enrichment ...app_context.Co
 		middleware.Recoverer,
 		// Set a timeout value on the request context (ctx), that will signal
 		// through ctx.Done() that the request has timed out and further
-		// processing should be stopped.
-		middleware.Timeout(60*time.Second),
-		lib.AuthHttpMiddleware(auth0Client, auth0Secret, app_context.AuthTokenContextKey),
-		app_context.ContextMiddleware(enrichment...),
+		// processing should be stopped. The sockets are left out, they outlive it.
+		timeout(60*time.Second),
 	)
 
-	mux.Route("/@", admin.Router)
-	mux.Route("/virtual-tables", virtual_table.Route)
+	// Development tokens are issued without authentication.
+	if devAuthProvider, ok := authProvider.(*lib.DevAuthProvider); ok {
+		mux.Post("/@/dev-token", admin.DevTokenRoute(devAuthProvider))
+	}
+
+	mux.Group(func(router chi.Router) {
+		router.Use(
+			app_context.ContextMiddleware(enrichment...),
+			lib.AuthHttpMiddleware(authProvider, user.ValidateApiKey, app_context.AuthTokenContextKey),
+			user.LoginMiddleware,
+		)
+		router.Route("/@", admin.Router)
+		router.Route("/users", user.Route)
+		router.Route("/game-systems", game_system.Route)
+		router.Route("/tools/dice", dice.Route)
+		router.Route("/virtual-tables", virtual_table.Route)
+	})
 	return mux
 }
 
+// timeout is the timeout middleware, skipped by the websocket upgrades: their connection is hijacked and
+// their commands must not be canceled after the timeout.
+func timeout(timeout time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withTimeout := middleware.Timeout(timeout)(next)
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			withTimeout.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func GrpcServer(authProvider lib.AuthProvider, enrichment ...app_context.ContextEnrichment) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			app_context.ContextUnaryInterceptor(enrichment...),
+			lib.AuthUnaryInterceptor(authProvider, user.ValidateApiKey, app_context.AuthTokenContextKey),
+			user.LoginUnaryInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			app_context.ContextStreamInterceptor(enrichment...),
+			lib.AuthStreamInterceptor(authProvider, user.ValidateApiKey, app_context.AuthTokenContextKey),
+			user.LoginStreamInterceptor,
+		),
+	)
+	virtual_table.RegisterGrpc(server)
+	return server
+}
+
 func main() {
 	httpPort := flag.Int("http-port", 8080, "port to bind (default: 8080)")
+	grpcPort := flag.Int("grpc-port", 9090, "grpc port to bind (default: 9090)")
 	natsUri := flag.String("nats-uri", "127.0.0.1:4222", "nats address (default: 127.0.0.1:4222)")
 	mongodbUri := flag.String("mongo-uri", "mongodb://127.0.0.1:27017/rpg-tools", "mongodb address (default: mongodb://127.0.0.1:27017/rpg-tools)")
-	auth0ClientId := flag.String("auth0-client-id", "", "auth0 client id")
-	auth0ClientSecret := flag.String("auth0-client-secret", "", "auth0 client secret")
+	authMode := flag.String("auth-mode", "oidc", "authentication mode, oidc or dev (default: oidc)")
+	devAuthAlg := flag.String("dev-auth-alg", "HS256", "dev tokens signing method, HS256 or RS256 (default: HS256)")
+	devAuthSecret := flag.String("dev-auth-secret", "dev-secret", "dev tokens HS256 secret (default: dev-secret)")
+	devAuthKey := flag.String("dev-auth-key", "", "dev tokens RS256 private key pem file, generated when empty")
+	oidcIssuer := flag.String("oidc-issuer", "https://dohrm.eu.auth0.com/", "expected token issuer (default: https://dohrm.eu.auth0.com/)")
+	oidcAudience := flag.String("oidc-audience", "", "expected token audience, not checked when empty")
+	oidcJwksUrl := flag.String("oidc-jwks-url", "", "jwks url (default: <oidc-issuer>.well-known/jwks.json)")
+	trashRetention := flag.Duration("trash-retention", virtual_table.DefaultTrashRetention, "delay before the deleted tables are purged (default: 720h)")
 
 	flag.Parse()
 
@@ -70,35 +130,70 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	err = mongoClient.Connect(ctx)
+	cancel()
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer func() {
-		ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 		_ = mongoClient.Disconnect(ctx)
 	}()
 	database := mongoClient.Database(cstring.Database)
+	if err := migrations.Run(context.Background(), database); err != nil {
+		log.Fatal(err)
+	}
 
-	// Auth0
-
-	log.Printf("auth0 client : %s, auth0 secret : %s", *auth0ClientId, *auth0ClientSecret)
+	// Auth
+	var authProvider lib.AuthProvider
+	switch *authMode {
+	case "oidc":
+		authConfig := lib.AuthConfig{Issuer: *oidcIssuer, Audience: *oidcAudience, JwksUrl: *oidcJwksUrl}
+		log.Printf("oidc issuer : %s, oidc audience : %s", authConfig.Issuer, authConfig.Audience)
+		authProvider = lib.NewOidcAuthProvider(authConfig)
+	case "dev":
+		log.Printf("development auth mode with %s tokens, never use it in production", *devAuthAlg)
+		authProvider, err = lib.NewDevAuthProvider(*devAuthAlg, *devAuthSecret, *devAuthKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown auth mode %s", *authMode)
+	}
 
 	enrichments := []app_context.ContextEnrichment{
 		app_context.WithNats(natsConn),
 		app_context.WithMongodb(database),
 	}
+	purgeCtx := context.Background()
+	for _, fn := range enrichments {
+		purgeCtx = fn(purgeCtx)
+	}
+	virtual_table.StartPurge(*trashRetention, purgeCtx)
+
 	// Init router
-	router := Router(*auth0ClientId, *auth0ClientSecret, enrichments...)
+	router := Router(authProvider, enrichments...)
+	grpcServer := GrpcServer(authProvider, enrichments...)
 
-	errors := make(chan error, 1)
+	errors := make(chan error, 2)
 
 	go func() {
 		log.Printf("start http server, listen :%d", *httpPort)
 		errors <- http.ListenAndServe(fmt.Sprintf(":%d", *httpPort), router)
 	}()
 
+	go func() {
+		log.Printf("start grpc server, listen :%d", *grpcPort)
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
+		if err != nil {
+			errors <- err
+			return
+		}
+		errors <- grpcServer.Serve(lis)
+	}()
+
 	err = <-errors
 
 	if err != nil {
@@ -0,0 +1,124 @@
+package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
+)
+
+// userIdentityBySubject rewrites the emails stored in the tables and their journal to user ids.
+// A user is created for the emails never seen at login, it is linked to its subject on its next login.
+func userIdentityBySubject(ctx context.Context, db *mongo.Database) error {
+	ids := make(map[string]string)
+	userId := func(email string) (string, error) {
+		if email == "" || email == "*" || !strings.Contains(email, "@") {
+			return email, nil
+		}
+		if id, ok := ids[email]; ok {
+			return id, nil
+		}
+		now := time.Now()
+		update := bson.M{"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "name": email, "picture": "", "createdAt": now, "lastLoginAt": now}}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+		res := struct {
+			Id primitive.ObjectID `bson:"_id"`
+		}{}
+		if err := db.Collection("users").FindOneAndUpdate(ctx, bson.M{"email": email}, update, opts).Decode(&res); err != nil {
+			return "", err
+		}
+		ids[email] = res.Id.Hex()
+		return ids[email], nil
+	}
+	rewrite := func(doc bson.M, key string) error {
+		switch v := doc[key].(type) {
+		case string:
+			id, err := userId(v)
+			if err != nil {
+				return err
+			}
+			doc[key] = id
+		case bson.A:
+			for idx, item := range v {
+				if s, ok := item.(string); ok {
+					id, err := userId(s)
+					if err != nil {
+						return err
+					}
+					v[idx] = id
+				}
+			}
+		}
+		return nil
+	}
+	rewriteAll := func(collection string, fn func(doc bson.M) error) error {
+		cursor, err := db.Collection(collection).Find(ctx, bson.M{})
+		if err != nil {
+			return err
+		}
+		defer func() { _ = cursor.Close(ctx) }()
+		for cursor.Next(ctx) {
+			doc := bson.M{}
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			if err := fn(doc); err != nil {
+				return err
+			}
+			if _, err := db.Collection(collection).ReplaceOne(ctx, bson.M{"_id": doc["_id"]}, doc); err != nil {
+				return err
+			}
+		}
+		return cursor.Err()
+	}
+
+	if err := rewriteAll("tables", func(table bson.M) error {
+		if err := rewrite(table, "master"); err != nil {
+			return err
+		}
+		if err := rewrite(table, "players"); err != nil {
+			return err
+		}
+		characters, _ := table["characters"].(bson.A)
+		for _, c := range characters {
+			if character, ok := c.(bson.M); ok {
+				if err := rewrite(character, "player"); err != nil {
+					return err
+				}
+			}
+		}
+		discussions, _ := table["discussions"].(bson.A)
+		for _, d := range discussions {
+			discussion, ok := d.(bson.M)
+			if !ok {
+				continue
+			}
+			if err := rewrite(discussion, "between"); err != nil {
+				return err
+			}
+			messages, _ := discussion["messages"].(bson.A)
+			for _, m := range messages {
+				if message, ok := m.(bson.M); ok {
+					if err := rewrite(message, "by"); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return rewriteAll("tables_journal", func(evt bson.M) error {
+		for _, key := range []string{"_by", "allowUsers", "player"} {
+			if err := rewrite(evt, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tablesLobbyIndexes indexes the discoverable tables for the lobby search.
+func tablesLobbyIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("tables").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "lobby.description", Value: "text"},
+				{Key: "lobby.gameSystem", Value: "text"},
+				{Key: "lobby.tags", Value: "text"},
+			},
+			Options: options.Index().SetName("lobby_text"),
+		},
+		{
+			Keys:    bson.D{{Key: "settings.discoverable", Value: 1}, {Key: "lobby.gameSystem", Value: 1}, {Key: "lobby.language", Value: 1}},
+			Options: options.Index().SetName("lobby_filters"),
+		},
+	})
+	return err
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const mastersAudience = "@masters"
+
+// tablesMastersAudience replaces the masters frozen in the "Master" discussions and in the events
+// reserved to the masters by the `@masters` audience, resolved when read.
+func tablesMastersAudience(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("tables").Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+	for cursor.Next(ctx) {
+		table := bson.M{}
+		if err := cursor.Decode(&table); err != nil {
+			return err
+		}
+		master, _ := table["master"].(string)
+		masterDiscussions := bson.A{}
+		discussions, _ := table["discussions"].(bson.A)
+		for _, d := range discussions {
+			discussion, ok := d.(bson.M)
+			if !ok || discussion["name"] != "Master" {
+				continue
+			}
+			if between, _ := discussion["between"].(bson.A); len(between) == 1 && between[0] == master {
+				discussion["between"] = bson.A{mastersAudience}
+				masterDiscussions = append(masterDiscussions, discussion["id"])
+			}
+		}
+		if len(masterDiscussions) > 0 {
+			if _, err := db.Collection("tables").UpdateOne(ctx, bson.M{"_id": table["_id"]}, bson.M{"$set": bson.M{"discussions": discussions}}); err != nil {
+				return err
+			}
+		}
+
+		journal := db.Collection("tables_journal")
+		aboutPlayer := bson.A{"evt:join-requested", "evt:join-request-rejected", "evt:player-banned", "evt:player-unbanned", "evt:player-muted"}
+		reserved := bson.M{"tableId": table["_id"], "allowUsers": bson.M{"$ne": "*"}}
+		if _, err := journal.UpdateMany(ctx,
+			bson.M{"$and": bson.A{reserved, bson.M{"$or": bson.A{
+				bson.M{"_kind": bson.M{"$in": bson.A{"evt:invite-created", "evt:invite-revoked"}}},
+				bson.M{"_kind": "evt:player-sent-message", "discussion": bson.M{"$in": masterDiscussions}},
+			}}}},
+			bson.M{"$set": bson.M{"allowUsers": bson.A{mastersAudience}}},
+		); err != nil {
+			return err
+		}
+
+		// Events reserved to the masters and the player they are about.
+		players, err := journal.Distinct(ctx, "player", bson.M{"$and": bson.A{reserved, bson.M{"_kind": bson.M{"$in": aboutPlayer}}}})
+		if err != nil {
+			return err
+		}
+		for _, player := range players {
+			if _, err := journal.UpdateMany(ctx,
+				bson.M{"$and": bson.A{reserved, bson.M{"player": player, "_kind": bson.M{"$in": aboutPlayer}}}},
+				bson.M{"$set": bson.M{"allowUsers": bson.A{mastersAudience, player}}},
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return cursor.Err()
+}
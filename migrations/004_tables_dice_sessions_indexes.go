@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tablesDiceSessionsIndexes indexes the dice sessions by table, to find the one in progress, and allows a single
+// session in progress by table.
+func tablesDiceSessionsIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("tables_dice_sessions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tableId", Value: 1}, {Key: "endedAt", Value: 1}},
+			Options: options.Index().SetName("dice_sessions_table"),
+		},
+		// The sessions in progress are stored with a null end.
+		{
+			Keys:    bson.D{{Key: "tableId", Value: 1}},
+			Options: options.Index().SetName("dice_sessions_in_progress").SetUnique(true).SetPartialFilterExpression(bson.M{"endedAt": bson.M{"$type": "null"}}),
+		},
+	})
+	return err
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+	"time"
+)
+
+const (
+	collectionName = "migrations"
+)
+
+type migration struct {
+	Name string
+	Up   func(ctx context.Context, db *mongo.Database) error
+}
+
+// All migrations, in order. A migration is applied once, never remove nor reorder them.
+var all = []migration{
+	{Name: "001-user-identity-by-subject", Up: userIdentityBySubject},
+	{Name: "002-tables-lobby-indexes", Up: tablesLobbyIndexes},
+	{Name: "003-tables-masters-audience", Up: tablesMastersAudience},
+	{Name: "004-tables-dice-sessions-indexes", Up: tablesDiceSessionsIndexes},
+}
+
+type appliedMigration struct {
+	Name      string    `bson:"_id"`
+	AppliedAt time.Time `bson:"appliedAt"`
+}
+
+// Run applies the migrations not applied yet on the database.
+func Run(ctx context.Context, db *mongo.Database) error {
+	for _, m := range all {
+		err := db.Collection(collectionName).FindOne(ctx, bson.M{"_id": m.Name}).Err()
+		if err == nil {
+			continue
+		}
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+		log.Printf("apply migration %s", m.Name)
+		if err := m.Up(ctx, db); err != nil {
+			return err
+		}
+		if _, err := db.Collection(collectionName).InsertOne(ctx, &appliedMigration{Name: m.Name, AppliedAt: time.Now()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}